@@ -0,0 +1,385 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: quotes/v1/quote.proto
+
+package quotesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Quote is a single price point of a token in every supported currency.
+type Quote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Btc           float64                `protobuf:"fixed64,2,opt,name=btc,proto3" json:"btc,omitempty"`
+	Usd           float64                `protobuf:"fixed64,3,opt,name=usd,proto3" json:"usd,omitempty"`
+	Eur           float64                `protobuf:"fixed64,4,opt,name=eur,proto3" json:"eur,omitempty"`
+	Cny           float64                `protobuf:"fixed64,5,opt,name=cny,proto3" json:"cny,omitempty"`
+	Jpy           float64                `protobuf:"fixed64,6,opt,name=jpy,proto3" json:"jpy,omitempty"`
+	Krw           float64                `protobuf:"fixed64,7,opt,name=krw,proto3" json:"krw,omitempty"`
+	Eth           float64                `protobuf:"fixed64,8,opt,name=eth,proto3" json:"eth,omitempty"`
+	Gbp           float64                `protobuf:"fixed64,9,opt,name=gbp,proto3" json:"gbp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_quotes_v1_quote_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quote_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quote_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quote) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Quote) GetBtc() float64 {
+	if x != nil {
+		return x.Btc
+	}
+	return 0
+}
+
+func (x *Quote) GetUsd() float64 {
+	if x != nil {
+		return x.Usd
+	}
+	return 0
+}
+
+func (x *Quote) GetEur() float64 {
+	if x != nil {
+		return x.Eur
+	}
+	return 0
+}
+
+func (x *Quote) GetCny() float64 {
+	if x != nil {
+		return x.Cny
+	}
+	return 0
+}
+
+func (x *Quote) GetJpy() float64 {
+	if x != nil {
+		return x.Jpy
+	}
+	return 0
+}
+
+func (x *Quote) GetKrw() float64 {
+	if x != nil {
+		return x.Krw
+	}
+	return 0
+}
+
+func (x *Quote) GetEth() float64 {
+	if x != nil {
+		return x.Eth
+	}
+	return 0
+}
+
+func (x *Quote) GetGbp() float64 {
+	if x != nil {
+		return x.Gbp
+	}
+	return 0
+}
+
+// QuoteList is the response body of endpoints returning a series of quotes.
+type QuoteList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quotes        []*Quote               `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteList) Reset() {
+	*x = QuoteList{}
+	mi := &file_quotes_v1_quote_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteList) ProtoMessage() {}
+
+func (x *QuoteList) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quote_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteList.ProtoReflect.Descriptor instead.
+func (*QuoteList) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quote_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuoteList) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+// PairQuote is a point of a synthetic pair: the price of the base token in the quote token,
+// derived from both tokens' prices in a pivot currency.
+type PairQuote struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Price          float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	BasePrice      float64                `protobuf:"fixed64,3,opt,name=base_price,json=basePrice,proto3" json:"base_price,omitempty"`
+	QuotePrice     float64                `protobuf:"fixed64,4,opt,name=quote_price,json=quotePrice,proto3" json:"quote_price,omitempty"`
+	QuoteTimestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=quote_timestamp,json=quoteTimestamp,proto3" json:"quote_timestamp,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PairQuote) Reset() {
+	*x = PairQuote{}
+	mi := &file_quotes_v1_quote_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairQuote) ProtoMessage() {}
+
+func (x *PairQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quote_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairQuote.ProtoReflect.Descriptor instead.
+func (*PairQuote) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quote_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PairQuote) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PairQuote) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PairQuote) GetBasePrice() float64 {
+	if x != nil {
+		return x.BasePrice
+	}
+	return 0
+}
+
+func (x *PairQuote) GetQuotePrice() float64 {
+	if x != nil {
+		return x.QuotePrice
+	}
+	return 0
+}
+
+func (x *PairQuote) GetQuoteTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QuoteTimestamp
+	}
+	return nil
+}
+
+// PairQuoteList is the response body of endpoints returning a series of pair quotes.
+type PairQuoteList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quotes        []*PairQuote           `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairQuoteList) Reset() {
+	*x = PairQuoteList{}
+	mi := &file_quotes_v1_quote_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairQuoteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairQuoteList) ProtoMessage() {}
+
+func (x *PairQuoteList) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quote_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairQuoteList.ProtoReflect.Descriptor instead.
+func (*PairQuoteList) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quote_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PairQuoteList) GetQuotes() []*PairQuote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+var File_quotes_v1_quote_proto protoreflect.FileDescriptor
+
+const file_quotes_v1_quote_proto_rawDesc = "" +
+	"\n" +
+	"\x15quotes/v1/quote.proto\x12\tquotes.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd1\x01\n" +
+	"\x05Quote\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x10\n" +
+	"\x03btc\x18\x02 \x01(\x01R\x03btc\x12\x10\n" +
+	"\x03usd\x18\x03 \x01(\x01R\x03usd\x12\x10\n" +
+	"\x03eur\x18\x04 \x01(\x01R\x03eur\x12\x10\n" +
+	"\x03cny\x18\x05 \x01(\x01R\x03cny\x12\x10\n" +
+	"\x03jpy\x18\x06 \x01(\x01R\x03jpy\x12\x10\n" +
+	"\x03krw\x18\a \x01(\x01R\x03krw\x12\x10\n" +
+	"\x03eth\x18\b \x01(\x01R\x03eth\x12\x10\n" +
+	"\x03gbp\x18\t \x01(\x01R\x03gbp\"5\n" +
+	"\tQuoteList\x12(\n" +
+	"\x06quotes\x18\x01 \x03(\v2\x10.quotes.v1.QuoteR\x06quotes\"\xe0\x01\n" +
+	"\tPairQuote\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1d\n" +
+	"\n" +
+	"base_price\x18\x03 \x01(\x01R\tbasePrice\x12\x1f\n" +
+	"\vquote_price\x18\x04 \x01(\x01R\n" +
+	"quotePrice\x12C\n" +
+	"\x0fquote_timestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0equoteTimestamp\"=\n" +
+	"\rPairQuoteList\x12,\n" +
+	"\x06quotes\x18\x01 \x03(\v2\x14.quotes.v1.PairQuoteR\x06quotesB%Z#quotes/api/proto/quotes/v1;quotesv1b\x06proto3"
+
+var (
+	file_quotes_v1_quote_proto_rawDescOnce sync.Once
+	file_quotes_v1_quote_proto_rawDescData []byte
+)
+
+func file_quotes_v1_quote_proto_rawDescGZIP() []byte {
+	file_quotes_v1_quote_proto_rawDescOnce.Do(func() {
+		file_quotes_v1_quote_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_quotes_v1_quote_proto_rawDesc), len(file_quotes_v1_quote_proto_rawDesc)))
+	})
+	return file_quotes_v1_quote_proto_rawDescData
+}
+
+var file_quotes_v1_quote_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_quotes_v1_quote_proto_goTypes = []any{
+	(*Quote)(nil),                 // 0: quotes.v1.Quote
+	(*QuoteList)(nil),             // 1: quotes.v1.QuoteList
+	(*PairQuote)(nil),             // 2: quotes.v1.PairQuote
+	(*PairQuoteList)(nil),         // 3: quotes.v1.PairQuoteList
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_quotes_v1_quote_proto_depIdxs = []int32{
+	4, // 0: quotes.v1.Quote.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 1: quotes.v1.QuoteList.quotes:type_name -> quotes.v1.Quote
+	4, // 2: quotes.v1.PairQuote.timestamp:type_name -> google.protobuf.Timestamp
+	4, // 3: quotes.v1.PairQuote.quote_timestamp:type_name -> google.protobuf.Timestamp
+	2, // 4: quotes.v1.PairQuoteList.quotes:type_name -> quotes.v1.PairQuote
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_quotes_v1_quote_proto_init() }
+func file_quotes_v1_quote_proto_init() {
+	if File_quotes_v1_quote_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quotes_v1_quote_proto_rawDesc), len(file_quotes_v1_quote_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_quotes_v1_quote_proto_goTypes,
+		DependencyIndexes: file_quotes_v1_quote_proto_depIdxs,
+		MessageInfos:      file_quotes_v1_quote_proto_msgTypes,
+	}.Build()
+	File_quotes_v1_quote_proto = out.File
+	file_quotes_v1_quote_proto_goTypes = nil
+	file_quotes_v1_quote_proto_depIdxs = nil
+}
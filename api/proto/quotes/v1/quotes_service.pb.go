@@ -0,0 +1,439 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: quotes/v1/quotes_service.proto
+
+package quotesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetLatestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestRequest) Reset() {
+	*x = GetLatestRequest{}
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestRequest) ProtoMessage() {}
+
+func (x *GetLatestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quotes_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetLatestRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetByTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Maximum number of quotes. Defaults to 100 when no time range is given.
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByTokenRequest) Reset() {
+	*x = GetByTokenRequest{}
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByTokenRequest) ProtoMessage() {}
+
+func (x *GetByTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetByTokenRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quotes_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetByTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetByTokenRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetByTokenRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetByTokenRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCountRequest) Reset() {
+	*x = GetCountRequest{}
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountRequest) ProtoMessage() {}
+
+func (x *GetCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountRequest.ProtoReflect.Descriptor instead.
+func (*GetCountRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quotes_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCountRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCountResponse) Reset() {
+	*x = GetCountResponse{}
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountResponse) ProtoMessage() {}
+
+func (x *GetCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountResponse.ProtoReflect.Descriptor instead.
+func (*GetCountResponse) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quotes_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SubscribeQuotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []string               `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeQuotesRequest) Reset() {
+	*x = SubscribeQuotesRequest{}
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeQuotesRequest) ProtoMessage() {}
+
+func (x *SubscribeQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeQuotesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quotes_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubscribeQuotesRequest) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *SubscribeQuotesRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+// QuoteEvent is a quote saved for a token.
+type QuoteEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Quote *Quote                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	// Set, without a quote, when the replay of the token stopped at `stream.max_replay` quotes: the quotes
+	// saved after the last one received have to be fetched with GetByToken.
+	ReplayTruncated bool `protobuf:"varint,3,opt,name=replay_truncated,json=replayTruncated,proto3" json:"replay_truncated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuoteEvent) Reset() {
+	*x = QuoteEvent{}
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteEvent) ProtoMessage() {}
+
+func (x *QuoteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_quotes_v1_quotes_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteEvent.ProtoReflect.Descriptor instead.
+func (*QuoteEvent) Descriptor() ([]byte, []int) {
+	return file_quotes_v1_quotes_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QuoteEvent) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *QuoteEvent) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *QuoteEvent) GetReplayTruncated() bool {
+	if x != nil {
+		return x.ReplayTruncated
+	}
+	return false
+}
+
+var File_quotes_v1_quotes_service_proto protoreflect.FileDescriptor
+
+const file_quotes_v1_quotes_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1equotes/v1/quotes_service.proto\x12\tquotes.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15quotes/v1/quote.proto\"(\n" +
+	"\x10GetLatestRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9b\x01\n" +
+	"\x11GetByTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"'\n" +
+	"\x0fGetCountRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"(\n" +
+	"\x10GetCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"b\n" +
+	"\x16SubscribeQuotesRequest\x12\x16\n" +
+	"\x06tokens\x18\x01 \x03(\tR\x06tokens\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"u\n" +
+	"\n" +
+	"QuoteEvent\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12&\n" +
+	"\x05quote\x18\x02 \x01(\v2\x10.quotes.v1.QuoteR\x05quote\x12)\n" +
+	"\x10replay_truncated\x18\x03 \x01(\bR\x0freplayTruncated2\xa1\x02\n" +
+	"\rQuotesService\x12:\n" +
+	"\tGetLatest\x12\x1b.quotes.v1.GetLatestRequest\x1a\x10.quotes.v1.Quote\x12@\n" +
+	"\n" +
+	"GetByToken\x12\x1c.quotes.v1.GetByTokenRequest\x1a\x14.quotes.v1.QuoteList\x12C\n" +
+	"\bGetCount\x12\x1a.quotes.v1.GetCountRequest\x1a\x1b.quotes.v1.GetCountResponse\x12M\n" +
+	"\x0fSubscribeQuotes\x12!.quotes.v1.SubscribeQuotesRequest\x1a\x15.quotes.v1.QuoteEvent0\x01B%Z#quotes/api/proto/quotes/v1;quotesv1b\x06proto3"
+
+var (
+	file_quotes_v1_quotes_service_proto_rawDescOnce sync.Once
+	file_quotes_v1_quotes_service_proto_rawDescData []byte
+)
+
+func file_quotes_v1_quotes_service_proto_rawDescGZIP() []byte {
+	file_quotes_v1_quotes_service_proto_rawDescOnce.Do(func() {
+		file_quotes_v1_quotes_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_quotes_v1_quotes_service_proto_rawDesc), len(file_quotes_v1_quotes_service_proto_rawDesc)))
+	})
+	return file_quotes_v1_quotes_service_proto_rawDescData
+}
+
+var file_quotes_v1_quotes_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_quotes_v1_quotes_service_proto_goTypes = []any{
+	(*GetLatestRequest)(nil),       // 0: quotes.v1.GetLatestRequest
+	(*GetByTokenRequest)(nil),      // 1: quotes.v1.GetByTokenRequest
+	(*GetCountRequest)(nil),        // 2: quotes.v1.GetCountRequest
+	(*GetCountResponse)(nil),       // 3: quotes.v1.GetCountResponse
+	(*SubscribeQuotesRequest)(nil), // 4: quotes.v1.SubscribeQuotesRequest
+	(*QuoteEvent)(nil),             // 5: quotes.v1.QuoteEvent
+	(*timestamppb.Timestamp)(nil),  // 6: google.protobuf.Timestamp
+	(*Quote)(nil),                  // 7: quotes.v1.Quote
+	(*QuoteList)(nil),              // 8: quotes.v1.QuoteList
+}
+var file_quotes_v1_quotes_service_proto_depIdxs = []int32{
+	6, // 0: quotes.v1.GetByTokenRequest.from:type_name -> google.protobuf.Timestamp
+	6, // 1: quotes.v1.GetByTokenRequest.to:type_name -> google.protobuf.Timestamp
+	6, // 2: quotes.v1.SubscribeQuotesRequest.since:type_name -> google.protobuf.Timestamp
+	7, // 3: quotes.v1.QuoteEvent.quote:type_name -> quotes.v1.Quote
+	0, // 4: quotes.v1.QuotesService.GetLatest:input_type -> quotes.v1.GetLatestRequest
+	1, // 5: quotes.v1.QuotesService.GetByToken:input_type -> quotes.v1.GetByTokenRequest
+	2, // 6: quotes.v1.QuotesService.GetCount:input_type -> quotes.v1.GetCountRequest
+	4, // 7: quotes.v1.QuotesService.SubscribeQuotes:input_type -> quotes.v1.SubscribeQuotesRequest
+	7, // 8: quotes.v1.QuotesService.GetLatest:output_type -> quotes.v1.Quote
+	8, // 9: quotes.v1.QuotesService.GetByToken:output_type -> quotes.v1.QuoteList
+	3, // 10: quotes.v1.QuotesService.GetCount:output_type -> quotes.v1.GetCountResponse
+	5, // 11: quotes.v1.QuotesService.SubscribeQuotes:output_type -> quotes.v1.QuoteEvent
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_quotes_v1_quotes_service_proto_init() }
+func file_quotes_v1_quotes_service_proto_init() {
+	if File_quotes_v1_quotes_service_proto != nil {
+		return
+	}
+	file_quotes_v1_quote_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quotes_v1_quotes_service_proto_rawDesc), len(file_quotes_v1_quotes_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_quotes_v1_quotes_service_proto_goTypes,
+		DependencyIndexes: file_quotes_v1_quotes_service_proto_depIdxs,
+		MessageInfos:      file_quotes_v1_quotes_service_proto_msgTypes,
+	}.Build()
+	File_quotes_v1_quotes_service_proto = out.File
+	file_quotes_v1_quotes_service_proto_goTypes = nil
+	file_quotes_v1_quotes_service_proto_depIdxs = nil
+}
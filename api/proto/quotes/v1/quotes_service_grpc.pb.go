@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: quotes/v1/quotes_service.proto
+
+package quotesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QuotesService_GetLatest_FullMethodName       = "/quotes.v1.QuotesService/GetLatest"
+	QuotesService_GetByToken_FullMethodName      = "/quotes.v1.QuotesService/GetByToken"
+	QuotesService_GetCount_FullMethodName        = "/quotes.v1.QuotesService/GetCount"
+	QuotesService_SubscribeQuotes_FullMethodName = "/quotes.v1.QuotesService/SubscribeQuotes"
+)
+
+// QuotesServiceClient is the client API for QuotesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// QuotesService exposes the quote read actions and the live quote stream.
+type QuotesServiceClient interface {
+	// GetLatest returns the most recent quote of a token.
+	GetLatest(ctx context.Context, in *GetLatestRequest, opts ...grpc.CallOption) (*Quote, error)
+	// GetByToken returns quotes of a token in a time range, or the latest ones when no range is given.
+	GetByToken(ctx context.Context, in *GetByTokenRequest, opts ...grpc.CallOption) (*QuoteList, error)
+	// GetCount returns the number of stored quotes of a token.
+	GetCount(ctx context.Context, in *GetCountRequest, opts ...grpc.CallOption) (*GetCountResponse, error)
+	// SubscribeQuotes streams quotes as soon as they are saved, optionally replaying the ones saved after `since`.
+	SubscribeQuotes(ctx context.Context, in *SubscribeQuotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QuoteEvent], error)
+}
+
+type quotesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuotesServiceClient(cc grpc.ClientConnInterface) QuotesServiceClient {
+	return &quotesServiceClient{cc}
+}
+
+func (c *quotesServiceClient) GetLatest(ctx context.Context, in *GetLatestRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, QuotesService_GetLatest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotesServiceClient) GetByToken(ctx context.Context, in *GetByTokenRequest, opts ...grpc.CallOption) (*QuoteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteList)
+	err := c.cc.Invoke(ctx, QuotesService_GetByToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotesServiceClient) GetCount(ctx context.Context, in *GetCountRequest, opts ...grpc.CallOption) (*GetCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCountResponse)
+	err := c.cc.Invoke(ctx, QuotesService_GetCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotesServiceClient) SubscribeQuotes(ctx context.Context, in *SubscribeQuotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QuoteEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QuotesService_ServiceDesc.Streams[0], QuotesService_SubscribeQuotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeQuotesRequest, QuoteEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuotesService_SubscribeQuotesClient = grpc.ServerStreamingClient[QuoteEvent]
+
+// QuotesServiceServer is the server API for QuotesService service.
+// All implementations must embed UnimplementedQuotesServiceServer
+// for forward compatibility.
+//
+// QuotesService exposes the quote read actions and the live quote stream.
+type QuotesServiceServer interface {
+	// GetLatest returns the most recent quote of a token.
+	GetLatest(context.Context, *GetLatestRequest) (*Quote, error)
+	// GetByToken returns quotes of a token in a time range, or the latest ones when no range is given.
+	GetByToken(context.Context, *GetByTokenRequest) (*QuoteList, error)
+	// GetCount returns the number of stored quotes of a token.
+	GetCount(context.Context, *GetCountRequest) (*GetCountResponse, error)
+	// SubscribeQuotes streams quotes as soon as they are saved, optionally replaying the ones saved after `since`.
+	SubscribeQuotes(*SubscribeQuotesRequest, grpc.ServerStreamingServer[QuoteEvent]) error
+	mustEmbedUnimplementedQuotesServiceServer()
+}
+
+// UnimplementedQuotesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuotesServiceServer struct{}
+
+func (UnimplementedQuotesServiceServer) GetLatest(context.Context, *GetLatestRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatest not implemented")
+}
+func (UnimplementedQuotesServiceServer) GetByToken(context.Context, *GetByTokenRequest) (*QuoteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByToken not implemented")
+}
+func (UnimplementedQuotesServiceServer) GetCount(context.Context, *GetCountRequest) (*GetCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCount not implemented")
+}
+func (UnimplementedQuotesServiceServer) SubscribeQuotes(*SubscribeQuotesRequest, grpc.ServerStreamingServer[QuoteEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeQuotes not implemented")
+}
+func (UnimplementedQuotesServiceServer) mustEmbedUnimplementedQuotesServiceServer() {}
+func (UnimplementedQuotesServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeQuotesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuotesServiceServer will
+// result in compilation errors.
+type UnsafeQuotesServiceServer interface {
+	mustEmbedUnimplementedQuotesServiceServer()
+}
+
+func RegisterQuotesServiceServer(s grpc.ServiceRegistrar, srv QuotesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedQuotesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuotesService_ServiceDesc, srv)
+}
+
+func _QuotesService_GetLatest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotesServiceServer).GetLatest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotesService_GetLatest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotesServiceServer).GetLatest(ctx, req.(*GetLatestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuotesService_GetByToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotesServiceServer).GetByToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotesService_GetByToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotesServiceServer).GetByToken(ctx, req.(*GetByTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuotesService_GetCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotesServiceServer).GetCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotesService_GetCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotesServiceServer).GetCount(ctx, req.(*GetCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuotesService_SubscribeQuotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeQuotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuotesServiceServer).SubscribeQuotes(m, &grpc.GenericServerStream[SubscribeQuotesRequest, QuoteEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuotesService_SubscribeQuotesServer = grpc.ServerStreamingServer[QuoteEvent]
+
+// QuotesService_ServiceDesc is the grpc.ServiceDesc for QuotesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuotesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "quotes.v1.QuotesService",
+	HandlerType: (*QuotesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLatest",
+			Handler:    _QuotesService_GetLatest_Handler,
+		},
+		{
+			MethodName: "GetByToken",
+			Handler:    _QuotesService_GetByToken_Handler,
+		},
+		{
+			MethodName: "GetCount",
+			Handler:    _QuotesService_GetCount_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeQuotes",
+			Handler:       _QuotesService_SubscribeQuotes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "quotes/v1/quotes_service.proto",
+}
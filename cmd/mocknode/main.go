@@ -0,0 +1,27 @@
+// Command mocknode serves the few node RPC endpoints the oracle pusher uses, so it can be run locally
+// without a Mavryk node. Every injected operation is logged and baked in a block of its own.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"quotes/internal/core/infrastructure/interactions/mavryk/mavryktest"
+)
+
+func main() {
+	addr := flag.String("addr", ":8732", "listen address")
+	flag.Parse()
+
+	log.Printf("Mock node listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, logRequests(mavryktest.NewNode())); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL.Path)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -18,13 +18,25 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"quotes/internal/config"
+	"quotes/internal/core/api/grpc"
 	"quotes/internal/core/api/http"
+	"quotes/internal/core/application/oracle/get_candles"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/cache"
+	"quotes/internal/core/infrastructure/interactions/remotesigner"
 	"quotes/internal/core/infrastructure/jobs"
+	"quotes/internal/core/infrastructure/pubsub"
+	"quotes/internal/core/infrastructure/signing"
 	"quotes/internal/core/infrastructure/storage"
+	"quotes/internal/core/infrastructure/storage/repositories"
+	"quotes/internal/lifecycle"
+	"strings"
 	"syscall"
 	"time"
 
@@ -32,6 +44,11 @@ import (
 )
 
 func main() {
+	exitCode := 0
+	defer func() {
+		os.Exit(exitCode)
+	}()
+
 	cfg, err := config.Load("config.yaml")
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
@@ -47,37 +64,125 @@ func main() {
 		}
 	}()
 
-	httpApp := http.NewApp(cfg, db.DB)
+	// Baskets are served like collected tokens, so they are registered before anything serves quotes
+	baskets, err := jobs.LoadBaskets(cfg)
+	if err != nil {
+		log.Fatalf("Invalid basket configuration: %v", err)
+	}
+	basketRepo := repositories.NewBasketRepository(db.DB)
+	ready := baskets[:0]
+	for _, basket := range baskets {
+		if err := basketRepo.EnsureTable(context.Background(), basket.Name); err != nil {
+			log.Printf("Skipping basket %s, its table could not be prepared (is migration 006 applied?): %v", basket.Name, err)
+			continue
+		}
+		quotes.RegisterBasket(basket.Name)
+		ready = append(ready, basket)
+	}
+	baskets = ready
 
-	quotesCollector := jobs.NewQuotesCollector(cfg, db.DB)
+	quoteCache := cache.NewQuoteCache(cfg, repositories.NewQuoteRepository(db.DB))
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Fans out newly saved quotes to stream clients
+	hub := pubsub.NewHub()
 
-	go func() {
-		if err := httpApp.Run(); err != nil {
-			log.Fatalf("HTTP server failed: %v", err)
-		}
-	}()
+	// Oracle attestations are signed by the configured signer; without one the endpoints are not served
+	signer, err := newOracleSigner(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up oracle signer: %v", err)
+	}
 
-	// Start quotes collector (may perform backfill)
-	quotesCollector.Start(ctx)
+	assets, err := harbingerAssets(cfg)
+	if err != nil {
+		log.Fatalf("Invalid Harbinger feed configuration: %v", err)
+	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	httpApp, err := http.NewApp(cfg, db.DB, quoteCache, hub, signer, assets)
+	if err != nil {
+		log.Fatalf("Failed to set up HTTP API: %v", err)
+	}
 
-	log.Println("Shutting down server...")
+	// Baskets are computed after each collection cycle of their constituents
+	basketCalculator := jobs.NewBasketCalculator(cfg, db.DB, baskets, quoteCache, hub)
+	quotesCollector := jobs.NewQuotesCollector(cfg, db.DB, quoteCache, hub, basketCalculator)
+
+	// Components stop in reverse order: servers drain their requests before the collector stops
+	manager := lifecycle.NewManager(cfg.GetShutdownTimeout())
+	manager.Add("quotes collector", quotesCollector)
+	if cfg.Oracle.Pusher.Enabled {
+		if signer == nil {
+			log.Fatalf("The oracle pusher needs an oracle signer")
+		}
+		oraclePusher, err := jobs.NewOraclePusher(cfg, db.DB, signer)
+		if err != nil {
+			log.Fatalf("Invalid oracle pusher configuration: %v", err)
+		}
+		manager.Add("oracle pusher", oraclePusher)
+	}
+	if cfg.Proofs.Enabled {
+		priceCommitter, err := jobs.NewPriceCommitter(cfg, db.DB)
+		if err != nil {
+			log.Fatalf("Invalid proofs configuration: %v", err)
+		}
+		manager.Add("price committer", priceCommitter)
+	}
+	if cfg.GRPC.Enabled {
+		manager.Add("gRPC server", grpc.NewServer(cfg, db.DB, quoteCache, hub))
+	}
+	manager.Add("HTTP server", httpApp)
 
-	quotesCollector.Stop()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
+	if err := manager.Run(ctx); err != nil {
+		log.Printf("Shutdown failed: %v", err)
+		exitCode = 1
+	}
+}
 
-	select {
-	case <-shutdownCtx.Done():
-		log.Println("Shutdown timeout exceeded")
+// newOracleSigner builds the configured signer and checks its key against oracle.public_key_hash
+func newOracleSigner(cfg *config.Config) (*signing.Signer, error) {
+	var backend signing.Backend
+	switch cfg.Oracle.Signer {
+	case "":
+		return nil, nil
+	case "local":
+		local, err := signing.NewLocalSigner(cfg.Oracle.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+		backend = local
+	case "remote":
+		if cfg.Oracle.Remote.URL == "" || cfg.Oracle.PublicKeyHash == "" {
+			return nil, errors.New("the remote signer needs oracle.remote.url and oracle.public_key_hash")
+		}
+		backend = remotesigner.NewClient(cfg.Oracle.Remote.URL, cfg.Oracle.PublicKeyHash, cfg.GetRemoteSignerTimeout(), cfg.Oracle.Remote.Retries)
 	default:
-		log.Println("Server shutdown complete")
+		return nil, fmt.Errorf("unknown oracle signer %q: use local or remote", cfg.Oracle.Signer)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	signer, err := signing.NewSigner(ctx, backend, cfg.Oracle.PublicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Oracle attestations signed by %s (%s signer)", signer.Key().PublicKeyHash, cfg.Oracle.Signer)
+	return signer, nil
+}
+
+// harbingerAssets resolves oracle.harbinger.assets; a missing currency is oracle.default_currency
+func harbingerAssets(cfg *config.Config) ([]get_candles.Asset, error) {
+	assets := make([]get_candles.Asset, len(cfg.Oracle.Harbinger.Assets))
+	for i, asset := range cfg.Oracle.Harbinger.Assets {
+		token, currency := strings.ToLower(asset.Token), strings.ToLower(asset.Currency)
+		if currency == "" {
+			currency = cfg.Oracle.DefaultCurrency
+		}
+		if !quotes.IsTokenSupported(token) || !quotes.IsCurrencySupported(currency) {
+			return nil, fmt.Errorf("unsupported asset %s/%s", asset.Token, asset.Currency)
+		}
+		assets[i] = get_candles.Asset{Token: token, Currency: quotes.Currency(currency)}
 	}
+	return assets, nil
 }
@@ -24,6 +24,55 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/graphql": {
+            "post": {
+                "description": "Executes a GraphQL query ({\"query\": \"...\", \"variables\": {...}, \"operationName\": \"...\"}) against the quotes schema:\nlatest(tokens), quotes(token, from, to, limit), count(token), tokens. GET takes the same fields as query parameters.\nQueries whose estimated cost exceeds graphql.max_complexity or whose depth exceeds graphql.max_depth are rejected.\nAutomatic persisted queries are supported through extensions.persistedQuery.sha256Hash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "GraphQL endpoint",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GraphQL result (data and errors)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, too deep or too complex query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request body larger than 1 MiB",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/quotes": {
             "get": {
                 "description": "Retrieve quotes for MVRK token with optional filters. Returns quotes within the specified time range.",
@@ -31,7 +80,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/msgpack",
+                    "application/x-protobuf",
+                    "application/vnd.apache.arrow.stream"
                 ],
                 "tags": [
                     "quotes"
@@ -40,16 +92,22 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start time (RFC3339 format, e.g., 2025-01-01T00:00:00Z). Default: 24 hours ago",
+                        "description": "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'",
                         "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "End time (RFC3339 format, e.g., 2025-01-01T23:59:59Z). Default: now",
+                        "description": "End time, same formats as 'from'. Default: now",
                         "name": "to",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Maximum number of quotes to return. Default: no limit",
@@ -65,24 +123,35 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/quotes.Quote"
                             }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Long and immutable for finalized windows, short otherwise"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong entity tag of the representation"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Timestamp of the newest quote in the response"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not modified (If-None-Match / If-Modified-Since matched)"
+                    },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -112,13 +181,10 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -131,7 +197,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/msgpack",
+                    "application/x-protobuf",
+                    "application/vnd.apache.arrow.stream"
                 ],
                 "tags": [
                     "quotes"
@@ -142,33 +211,194 @@ const docTemplate = `{
                         "description": "Latest quote",
                         "schema": {
                             "$ref": "#/definitions/quotes.Quote"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Long and immutable for finalized windows, short otherwise"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong entity tag of the representation"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Timestamp of the newest quote in the response"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "304": {
+                        "description": "Not modified (If-None-Match / If-Modified-Since matched)"
+                    },
+                    "404": {
+                        "description": "No quotes stored yet (NO_DATA)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
             }
         },
-        "/{token}": {
+        "/v1/convert": {
             "get": {
-                "description": "Retrieve quotes for a specific token (mvrk, usdt, etc.) with optional filters",
-                "consumes": [
+                "description": "Converts between a token and a currency (mvrk -\u003e eur, eur -\u003e mvrk) or between two tokens through a currency priced for both, USD first (mvrk -\u003e usd -\u003e usdt).\nUses the latest quotes, or with \"at\" the last quotes at most conversion.max_quote_age_seconds before that moment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Convert an amount between tokens and currencies",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Amount to convert (default 1)",
+                        "name": "amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token or currency to convert from (e.g., mvrk)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token or currency to convert to (e.g., eur, usdt)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Moment of the quotes: RFC3339, date (2025-01-01, in tz), unix seconds/milliseconds or relative (now-1h). Default: latest quotes",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/convert.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No quote for a token at that moment (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/harbinger/info": {
+            "get": {
+                "description": "Feed name, asset names and public key of the Harbinger-compatible candle feed, as a Harbinger signer ` + "`" + `/info` + "`" + ` endpoint returns them.\n` + "`" + `volumeMethod` + "`" + ` tells how candle volumes are derived: quotes carry the rolling 24h USD volume only, so a candle volume is that volume prorated to the candle length, in token units, and never below 1.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "oracle"
+                ],
+                "summary": "Harbinger-compatible feed description",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_feed_info.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/harbinger/oracle": {
+            "get": {
+                "description": "Latest completed candle of every configured asset (e.g. MVRK-USD), in the message and signature format of a Harbinger signer ` + "`" + `/oracle` + "`" + ` endpoint, for existing Harbinger oracle contracts, normalizers and posters.\nEach message is the hex PACK of ` + "`" + `pair string (pair timestamp (pair timestamp (pair nat (pair nat (pair nat (pair nat nat))))))` + "`" + `: asset, start, end, open, high, low, close and volume, prices and volume with 6 decimals.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tokens"
+                    "oracle"
                 ],
-                "summary": "Get quotes for a specific token",
+                "summary": "Harbinger-compatible signed candles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_candles.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "No observed price for any asset (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database or signer unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/oracle/key": {
+            "get": {
+                "description": "Public key that signs oracle attestations, with its algorithm and key hash, for contracts and clients to pin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oracle"
+                ],
+                "summary": "Oracle public key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/oracle.Key"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/oracle/{token}": {
+            "get": {
+                "description": "Latest observed price of a token, packed as Michelson bytes (pair timestamp string string nat) and signed by the oracle signer (remote signer or local key).\nThe signature verifies with CHECK_SIGNATURE on the packed bytes, or ` + "`" + `octez-client check that bytes \u003cpacked\u003e were signed by \u003cpublic_key\u003e to produce \u003csignature\u003e` + "`" + `.\nForward-filled prices are never attested: the payload carries the time the price was last observed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "oracle"
+                ],
+                "summary": "Signed price attestation for on-chain use",
                 "parameters": [
                     {
                         "type": "string",
@@ -179,96 +409,1572 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Start time (RFC3339 format, e.g., 2025-01-01T00:00:00Z). Default: 24 hours ago",
+                        "description": "Currency (default oracle.default_currency)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_attestation.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND) or no observed price (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database or signer unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/pairs/{base}/{quote}": {
+            "get": {
+                "description": "Derives the price of the base token in the quote token (e.g., MVRK/USDT) by aligning both token tables on timestamp and dividing their prices in a pivot currency.\nEvery base quote is matched with the nearest quote token quote at most \"tolerance\" away; base quotes without a match are skipped.\nPagination and defaults are the same as GET /{token}: without 'from' and 'to', returns the latest quotes.",
+                "produces": [
+                    "application/json",
+                    "application/msgpack",
+                    "application/x-protobuf",
+                    "application/vnd.apache.arrow.stream"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Synthetic pair between two tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base token (e.g., mvrk)",
+                        "name": "base",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote token (e.g., usdt)",
+                        "name": "quote",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency both prices are taken in (default usd)",
+                        "name": "pivot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Largest distance between aligned quotes, as a duration (30s, 1m) or seconds. Default: pairs.default_tolerance_seconds",
+                        "name": "tolerance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'. Without 'from' and 'to', returns latest quotes",
                         "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "End time (RFC3339 format, e.g., 2025-01-01T23:59:59Z). Default: now",
+                        "description": "End time, same formats as 'from'. Default: now",
                         "name": "to",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Maximum number of quotes to return. Default: no limit",
+                        "description": "Maximum number of quotes to return. Default: 100 when no time range specified, no limit when time range is specified",
                         "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of quotes",
+                        "description": "Pair quotes",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/quotes.Quote"
+                                "$ref": "#/definitions/quotes.PairQuote"
+                            }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Long and immutable for finalized windows, short otherwise"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong entity tag of the representation"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Timestamp of the newest quote in the response"
                             }
                         }
                     },
+                    "304": {
+                        "description": "Not modified (If-None-Match / If-Modified-Since matched)"
+                    },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
                     "404": {
-                        "description": "Token not found",
+                        "description": "Token not found (TOKEN_NOT_FOUND)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "quotes.Quote": {
-            "type": "object",
-            "properties": {
-                "btc": {
-                    "type": "number"
-                },
-                "cny": {
-                    "type": "number"
-                },
-                "eth": {
-                    "type": "number"
-                },
-                "eur": {
-                    "type": "number"
-                },
-                "gbp": {
-                    "type": "number"
-                },
-                "jpy": {
-                    "type": "number"
-                },
-                "krw": {
-                    "type": "number"
-                },
-                "timestamp": {
-                    "type": "string"
-                },
-                "usd": {
+        },
+        "/v1/proofs/{token}": {
+            "get": {
+                "description": "Proves that the quote of a token at ` + "`" + `ts` + "`" + ` (or the last one before it on the same UTC day) is part of the daily Merkle commitment of that day.\nVerify by hashing ` + "`" + `leaf` + "`" + ` as SHA-256(0x00 || leaf), then for each step SHA-256(0x01 || sibling || hash) when ` + "`" + `position` + "`" + ` is left, or SHA-256(0x01 || hash || sibling) when right; the result must equal ` + "`" + `root` + "`" + `.\nA day is committed once it is over and its quotes are final, when proofs.enabled is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "proofs"
+                ],
+                "summary": "Inclusion proof of a stored quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time of the quote: RFC3339, date, unix seconds or milliseconds, or relative time",
+                        "name": "ts",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_proof.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND), day not committed or no quote (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Stored quotes no longer match the committed root (COMMITMENT_MISMATCH)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream": {
+            "get": {
+                "description": "Upgrades to a WebSocket that pushes every new quote as soon as it is saved.\nClient messages: {\"action\":\"subscribe\",\"tokens\":[\"mvrk\"],\"currencies\":[\"usd\"],\"since\":\"2025-01-01T00:00:00Z\"} and {\"action\":\"unsubscribe\",\"tokens\":[\"mvrk\"]}.\nServer messages have a \"type\": quote, subscribed, unsubscribed, replay_truncated, heartbeat or error.\n\"since\" replays the quotes saved after that time before live ones. Clients that fall behind are disconnected with close code 1013.",
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Live quote stream (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tokens to subscribe to on connect (e.g., mvrk,usdt)",
+                        "name": "tokens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated currencies to include (default: all)",
+                        "name": "currencies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume point (any time parameter format, e.g. RFC3339 or -1h); quotes saved after it are replayed first",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols"
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tickers": {
+            "get": {
+                "description": "Latest price of every enabled token in each currency, with absolute and percentage changes over 1h, 24h and 7d.\nChanges compare the latest quote with the last quote at or before the same time 1h, 24h or 7d earlier.\nTokens without quotes yet are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Tickers of all enabled tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_tickers.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tokens/{token}/valuations": {
+            "post": {
+                "description": "Values every (timestamp, amount) entry with the last quote of the token at or before the timestamp, in one database query.\nThe body is JSON ({\"entries\": [{\"timestamp\", \"amount\"}], \"currencies\": [...]}) or CSV (Content-Type text/csv, columns timestamp,amount, optional header).\nEntries whose quote is older than valuations.max_quote_age_seconds or forward-filled in a requested currency are flagged as stale but still valued; entries without any quote are flagged and not valued.\nA requested currency the quote has no price in is listed in the row's missing_currencies, left out of its values and totals, and flags the row as stale.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Value timestamped amounts of a token in fiat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated currencies (default usd)",
+                        "name": "currencies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for timestamps without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Entries to value",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/valuate.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/valuate.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or parameters (INVALID_PARAMETER)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Body larger than 256 bytes per allowed entry (REQUEST_TOO_LARGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/{token}": {
+            "get": {
+                "description": "Retrieve quotes for a specific token (mvrk, usdt, etc.) with optional filters. If no time range is specified, returns the latest 100 quotes by default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/msgpack",
+                    "application/x-protobuf",
+                    "application/vnd.apache.arrow.stream"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Get quotes for a specific token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'. Without 'from' and 'to', returns latest quotes",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time, same formats as 'from'. Default: now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of quotes to return. Default: 100 when no time range specified, no limit when time range is specified",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of quotes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/quotes.Quote"
+                            }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Long and immutable for finalized windows, short otherwise"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Strong entity tag of the representation"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Timestamp of the newest quote in the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified (If-None-Match / If-Modified-Since matched)"
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/{token}/daily": {
+            "get": {
+                "description": "One price per calendar day in the time zone \"tz\": the close (last observed price), the open (first observed price) or the average of the day.\nDays follow the local calendar, so they are 23 or 25 hours long across DST changes. Forward-filled prices are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Daily prices of a token in a local time zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (default usd)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of the days (e.g., Asia/Seoul). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "close, open or average. Default: daily.default_method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A time in the first day: RFC3339, date (2025-01-01, in tz), unix seconds/milliseconds or relative (now-30d). Default: 24 hours before 'to'",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A time in the last day, same formats as 'from'. Default: now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_daily.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/{token}/events": {
+            "get": {
+                "description": "Streams every new quote of the token as a \"quote\" event whose id is the quote's unix timestamp.\nReconnecting clients send Last-Event-ID to replay the quotes saved after it. Clients that fall behind are disconnected and should reconnect.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Live quotes for a token (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last received event (unix timestamp)",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Last-Event-ID (INVALID_PARAMETER)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many subscribers (TOO_MANY_SUBSCRIBERS)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/{token}/stats": {
+            "get": {
+                "description": "Min, max, mean, standard deviation, change, realized volatility and max drawdown of the price in one currency.\nComputed in the database over observed quotes; forward-filled prices are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Price statistics of a token over a range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time, same formats as 'from'. Default: now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (default usd)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_stats.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND) or no quotes in the range (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/{token}/twap": {
+            "get": {
+                "description": "Averages the price over the window ending at \"at\", weighting each observed price by how long it was the latest one.\nA price stays fresh until the next quote or for averages.max_gap_seconds; longer gaps don't count (see gap_seconds).\nForward-filled prices are not observations and are ignored.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Time-weighted average price of a token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (default usd)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window length as a duration (30m, 1h) or seconds. Default: averages.default_window_seconds",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-1h, now-1d). Default: now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_average.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND) or no quotes in the window (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/{token}/vwap24h": {
+            "get": {
+                "description": "Averages the price over the window ending at \"at\", weighting each observed price by the rolling 24h USD volume reported with it.\nThis is not a VWAP of the trades in the window: the upstream only reports 24h volumes. Quotes without a volume and forward-filled prices are ignored.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Average price of a token weighted by the rolling 24h volume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token name (e.g., mvrk, usdt)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (default usd)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window length as a duration (30m, 1h) or seconds. Default: averages.default_window_seconds",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-1h, now-1d). Default: now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/get_average.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found (TOKEN_NOT_FOUND) or no quotes in the window (NO_DATA)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable (UPSTREAM_UNAVAILABLE)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apierror.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "TOKEN_NOT_FOUND"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "token 'abc' is not supported"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f9c2a7d1e3b8a60"
+                }
+            }
+        },
+        "convert.Leg": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "eur"
+                },
+                "inverse": {
+                    "description": "The price was inverted (currency to token)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "price": {
+                    "type": "number",
+                    "example": 0.0712
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-01-01T11:59:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                }
+            }
+        },
+        "convert.Response": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "at": {
+                    "description": "Requested moment, omitted for latest quotes",
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "mvrk"
+                },
+                "path": {
+                    "description": "Symbols the conversion goes through",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mvrk",
+                        "eur"
+                    ]
+                },
+                "quotes": {
+                    "description": "Quote prices used, in path order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/convert.Leg"
+                    }
+                },
+                "rate": {
+                    "description": "Units of \"to\" per unit of \"from\"",
+                    "type": "number",
+                    "example": 0.0712
+                },
+                "result": {
+                    "type": "number",
+                    "example": 0.89
+                },
+                "to": {
+                    "type": "string",
+                    "example": "eur"
+                }
+            }
+        },
+        "get_attestation.Response": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "michelson": {
+                    "type": "string",
+                    "example": "Pair 1735689600 \"mvrk\" \"usd\" 12345678"
+                },
+                "michelson_type": {
+                    "type": "string",
+                    "example": "pair timestamp string string nat"
+                },
+                "packed": {
+                    "description": "PACK of the payload",
+                    "type": "string",
+                    "example": "0x050707008096a4f70c070701000000046d76726b07070100000003757364008e85e30b"
+                },
+                "price": {
+                    "description": "Signed price as a decimal, for display",
+                    "type": "number",
+                    "example": 0.12345678
+                },
+                "price_nat": {
+                    "description": "Signed price: price x 10^decimals",
+                    "type": "string",
+                    "example": "12345678"
+                },
+                "public_key": {
+                    "type": "string",
+                    "example": "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"
+                },
+                "public_key_hash": {
+                    "type": "string",
+                    "example": "mv18Cw7psUrAAPBpXYd9CtCpHg9EgjHP9KTe"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "edsigtnQ5MbiQfWqHPnb9k1zh7W2vAu6sXqPRT1JiVZU2hoB4A89mizNhvFwEEMnBMabCKTjzQXuvw4rSgtAsmrZqgyVj5dL4WJ"
+                },
+                "timestamp": {
+                    "description": "Time the price was observed",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                }
+            }
+        },
+        "get_average.Response": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "effective_from": {
+                    "description": "Start of the part covered by contributing quotes",
+                    "type": "string",
+                    "example": "2025-01-01T11:30:00Z"
+                },
+                "effective_seconds": {
+                    "description": "Time during which a contributing price was fresh",
+                    "type": "integer",
+                    "example": 1800
+                },
+                "effective_to": {
+                    "description": "End of the part covered by contributing quotes",
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                },
+                "filled_points": {
+                    "description": "Forward-filled quotes in the window, ignored",
+                    "type": "integer",
+                    "example": 0
+                },
+                "from": {
+                    "description": "Start of the requested window",
+                    "type": "string",
+                    "example": "2025-01-01T11:30:00Z"
+                },
+                "gap_seconds": {
+                    "description": "Rest of the window, without a fresh price",
+                    "type": "integer",
+                    "example": 0
+                },
+                "method": {
+                    "type": "string",
+                    "example": "twap"
+                },
+                "points": {
+                    "description": "Quotes that contributed to the value",
+                    "type": "integer",
+                    "example": 30
+                },
+                "to": {
+                    "description": "End of the requested window (\"at\")",
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 0.0123
+                },
+                "window_seconds": {
+                    "description": "Length of the requested window",
+                    "type": "integer",
+                    "example": 1800
+                }
+            }
+        },
+        "get_candles.Response": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "05070701000000084d56524b2d555344..."
+                    ]
+                },
+                "signatures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "edsigtnQ5MbiQfWqHPnb9k1zh7W2vAu6sXqPRT1JiVZU2hoB4A89mizNhvFwEEMnBMabCKTjzQXuvw4rSgtAsmrZqgyVj5dL4WJ"
+                    ]
+                },
+                "timestamp": {
+                    "description": "End of the newest candle",
+                    "type": "string",
+                    "example": "2025-01-01T00:01:00.000Z"
+                }
+            }
+        },
+        "get_daily.Price": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "description": "Time of the quote the price comes from (open and close)",
+                    "type": "string",
+                    "example": "2025-01-01T14:59:00Z"
+                },
+                "complete": {
+                    "description": "False while the day is still running",
+                    "type": "boolean",
+                    "example": true
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "end": {
+                    "description": "Start of the next local day",
+                    "type": "string",
+                    "example": "2025-01-01T15:00:00Z"
+                },
+                "points": {
+                    "description": "Observed quotes in the day",
+                    "type": "integer",
+                    "example": 1440
+                },
+                "price": {
+                    "type": "number",
+                    "example": 17.85
+                },
+                "start": {
+                    "description": "Start of the local day",
+                    "type": "string",
+                    "example": "2024-12-31T15:00:00Z"
+                }
+            }
+        },
+        "get_daily.Response": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "krw"
+                },
+                "from": {
+                    "description": "First requested day",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "close"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/get_daily.Price"
+                    }
+                },
+                "to": {
+                    "description": "Last requested day",
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                },
+                "tz": {
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                }
+            }
+        },
+        "get_feed_info.Response": {
+            "type": "object",
+            "properties": {
+                "assetNames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "MVRK-USD"
+                    ]
+                },
+                "dataFeed": {
+                    "type": "string",
+                    "example": "Mavryk External Data"
+                },
+                "publicKey": {
+                    "description": "Key to originate the oracle contract with",
+                    "type": "string",
+                    "example": "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"
+                },
+                "volumeMethod": {
+                    "description": "How candle volumes are derived; not part of the Harbinger response",
+                    "type": "string",
+                    "example": "synthetic: rolling 24h USD volume of the candle quotes prorated to the candle length, in token units at the candle USD price, at least 1 (0.000001 token)"
+                }
+            }
+        },
+        "get_proof.Response": {
+            "type": "object",
+            "properties": {
+                "committed_at": {
+                    "type": "string",
+                    "example": "2025-01-02T00:10:00Z"
+                },
+                "day": {
+                    "description": "UTC day of the commitment",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "hash_algorithm": {
+                    "type": "string",
+                    "example": "sha256"
+                },
+                "index": {
+                    "description": "Position of the leaf, in timestamp order",
+                    "type": "integer",
+                    "example": 0
+                },
+                "leaf": {
+                    "type": "string",
+                    "example": "mvrk,1735689600,0.00000123,0.12345678,0.11800000,0.90000000,19.00000000,170.00000000,0.00003500,0.09800000"
+                },
+                "leaf_count": {
+                    "type": "integer",
+                    "example": 1440
+                },
+                "leaf_format": {
+                    "type": "string",
+                    "example": "token,unix_seconds,btc,usd,eur,cny,jpy,krw,eth,gbp (prices with 8 decimals)"
+                },
+                "leaf_hash": {
+                    "description": "SHA-256(0x00 || leaf)",
+                    "type": "string",
+                    "example": "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"
+                },
+                "proof": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/get_proof.Step"
+                    }
+                },
+                "quote": {
+                    "$ref": "#/definitions/quotes.Quote"
+                },
+                "root": {
+                    "type": "string",
+                    "example": "5feceb66ffc86f38d952786c6d696c79c2dbc239dd4e91b46729d73a27fb57e9"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                }
+            }
+        },
+        "get_proof.Step": {
+            "type": "object",
+            "properties": {
+                "hash": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "position": {
+                    "description": "Side the sibling is hashed on: left or right",
+                    "type": "string",
+                    "example": "left"
+                }
+            }
+        },
+        "get_stats.Response": {
+            "type": "object",
+            "properties": {
+                "annualized_volatility": {
+                    "description": "Volatility scaled to a year",
+                    "type": "number",
+                    "example": 0.8
+                },
+                "change_percent": {
+                    "description": "Change from first to last quote",
+                    "type": "number",
+                    "example": 5
+                },
+                "count": {
+                    "description": "Observed quotes in the range; forward-filled prices are left out",
+                    "type": "integer",
+                    "example": 1440
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "usd"
+                },
+                "first": {
+                    "type": "number",
+                    "example": 0.012
+                },
+                "first_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "last": {
+                    "type": "number",
+                    "example": 0.0126
+                },
+                "last_at": {
+                    "type": "string",
+                    "example": "2025-01-01T23:59:00Z"
+                },
+                "max": {
+                    "type": "number",
+                    "example": 0.0131
+                },
+                "max_at": {
+                    "type": "string",
+                    "example": "2025-01-01T17:40:00Z"
+                },
+                "max_drawdown": {
+                    "description": "Largest drop from a running peak, as a fraction of the peak",
+                    "type": "number",
+                    "example": 0.061
+                },
+                "max_drawdown_at": {
+                    "description": "Time of the trough",
+                    "type": "string",
+                    "example": "2025-01-01T09:05:00Z"
+                },
+                "mean": {
+                    "type": "number",
+                    "example": 0.0124
+                },
+                "min": {
+                    "type": "number",
+                    "example": 0.0118
+                },
+                "min_at": {
+                    "type": "string",
+                    "example": "2025-01-01T03:12:00Z"
+                },
+                "stddev": {
+                    "description": "Sample standard deviation",
+                    "type": "number",
+                    "example": 0.0003
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-02T00:00:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                },
+                "volatility": {
+                    "description": "Realized volatility over the range (square root of summed squared log returns)",
+                    "type": "number",
+                    "example": 0.042
+                }
+            }
+        },
+        "get_tickers.CurrencyTicker": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "change_percent": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "price": {
+                    "type": "number",
+                    "example": 0.0123
+                }
+            }
+        },
+        "get_tickers.Response": {
+            "type": "object",
+            "properties": {
+                "tickers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/get_tickers.Ticker"
+                    }
+                }
+            }
+        },
+        "get_tickers.Ticker": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "description": "Age of the latest quote",
+                    "type": "integer",
+                    "example": 42
+                },
+                "currencies": {
+                    "description": "Keyed by currency (btc, usd, ...)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/get_tickers.CurrencyTicker"
+                    }
+                },
+                "timestamp": {
+                    "description": "Time of the latest quote",
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                }
+            }
+        },
+        "oracle.Key": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "description": "ed25519, secp256k1 or p256",
+                    "type": "string",
+                    "example": "ed25519"
+                },
+                "public_key": {
+                    "description": "edpk..., sppk... or p2pk...",
+                    "type": "string",
+                    "example": "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"
+                },
+                "public_key_hash": {
+                    "description": "mv1..., mv2... or mv3...",
+                    "type": "string",
+                    "example": "mv18Cw7psUrAAPBpXYd9CtCpHg9EgjHP9KTe"
+                }
+            }
+        },
+        "quotes.PairQuote": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "number"
+                },
+                "price": {
+                    "description": "BasePrice / QuotePrice",
+                    "type": "number"
+                },
+                "quote_price": {
+                    "description": "QuotePrice comes from the quote token quote nearest to Timestamp, at QuoteTimestamp",
+                    "type": "number"
+                },
+                "quote_timestamp": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp of the base token quote",
+                    "type": "string"
+                }
+            }
+        },
+        "quotes.Quote": {
+            "type": "object",
+            "properties": {
+                "btc": {
+                    "type": "number"
+                },
+                "cny": {
+                    "type": "number"
+                },
+                "eth": {
+                    "type": "number"
+                },
+                "eur": {
+                    "type": "number"
+                },
+                "gbp": {
+                    "type": "number"
+                },
+                "jpy": {
+                    "type": "number"
+                },
+                "krw": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "usd": {
                     "type": "number"
                 }
             }
+        },
+        "valuate.Entry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "timestamp": {
+                    "description": "Any time parameter format, or unix seconds as a number",
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                }
+            }
+        },
+        "valuate.Request": {
+            "type": "object",
+            "properties": {
+                "currencies": {
+                    "description": "Overrides the currencies query parameter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "usd",
+                        "eur"
+                    ]
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/valuate.Entry"
+                    }
+                }
+            }
+        },
+        "valuate.Response": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "usd",
+                        "eur"
+                    ]
+                },
+                "missing_count": {
+                    "description": "Entries without any quote at or before their timestamp",
+                    "type": "integer",
+                    "example": 0
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/valuate.Row"
+                    }
+                },
+                "stale_count": {
+                    "description": "Entries whose quote is stale or missing",
+                    "type": "integer",
+                    "example": 0
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mvrk"
+                },
+                "total_amount": {
+                    "type": "number",
+                    "example": 25
+                },
+                "totals": {
+                    "description": "Sum of values per currency over entries with a price in it",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "valuate.Row": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "missing_currencies": {
+                    "description": "Requested currencies the quote has no price in, left out of values and totals",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gbp"
+                    ]
+                },
+                "quote_age_seconds": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "quote_timestamp": {
+                    "description": "Last quote at or before the timestamp",
+                    "type": "string",
+                    "example": "2025-01-01T11:59:00Z"
+                },
+                "stale": {
+                    "description": "The quote is older than valuations.max_quote_age_seconds, forward-filled, missing or lacks a currency",
+                    "type": "boolean",
+                    "example": false
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
         }
     }
 }`
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -11,18 +12,40 @@ import (
 )
 
 type Config struct {
-	Server    ServerConfig           `yaml:"server"`
-	Database  DatabaseConfig         `yaml:"database"`
-	Job       JobConfig              `yaml:"job"`
-	API       APIConfig              `yaml:"api"`
-	CoinGecko CoinGeckoConfig        `yaml:"coingecko"`
-	Backfill  BackfillConfig         `yaml:"backfill"`
-	Tokens    map[string]TokenConfig `yaml:"tokens"`
+	Server     ServerConfig            `yaml:"server"`
+	GRPC       GRPCConfig              `yaml:"grpc"`
+	Database   DatabaseConfig          `yaml:"database"`
+	Job        JobConfig               `yaml:"job"`
+	API        APIConfig               `yaml:"api"`
+	CoinGecko  CoinGeckoConfig         `yaml:"coingecko"`
+	Backfill   BackfillConfig          `yaml:"backfill"`
+	Cache      CacheConfig             `yaml:"cache"`
+	RateLimit  RateLimitConfig         `yaml:"rate_limit"`
+	CORS       CORSConfig              `yaml:"cors"`
+	Stream     StreamConfig            `yaml:"stream"`
+	GraphQL    GraphQLConfig           `yaml:"graphql"`
+	Averages   AveragesConfig          `yaml:"averages"`
+	Daily      DailyConfig             `yaml:"daily"`
+	Conversion ConversionConfig        `yaml:"conversion"`
+	Valuations ValuationsConfig        `yaml:"valuations"`
+	Pairs      PairsConfig             `yaml:"pairs"`
+	Oracle     OracleConfig            `yaml:"oracle"`
+	Proofs     ProofsConfig            `yaml:"proofs"`
+	Baskets    map[string]BasketConfig `yaml:"baskets"`
+	Tokens     map[string]TokenConfig  `yaml:"tokens"`
 }
 
 type ServerConfig struct {
-	Port string `yaml:"port"`
-	Host string `yaml:"host"`
+	Port           string   `yaml:"port"`
+	Host           string   `yaml:"host"`
+	TrustedProxies []string `yaml:"trusted_proxies"` // proxies allowed to set X-Forwarded-For (empty = trust none)
+
+	ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds"` // How long components may take to stop
+}
+
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled"` // Serve the gRPC API
+	Port    string `yaml:"port"`    // gRPC port (host is server.host)
 }
 
 type DatabaseConfig struct {
@@ -38,11 +61,13 @@ type DatabaseConfig struct {
 type JobConfig struct {
 	IntervalSeconds int  `yaml:"interval_seconds"`
 	Enabled         bool `yaml:"enabled"`
+	FinalitySeconds int  `yaml:"finality_seconds"` // quotes older than this are no longer rewritten by the collector
 }
 
 type APIConfig struct {
-	TimeoutSeconds int `yaml:"timeout_seconds"`
-	RateLimitRPS   int `yaml:"rate_limit_rps"`
+	TimeoutSeconds     int `yaml:"timeout_seconds"`
+	RateLimitRPS       int `yaml:"rate_limit_rps"`
+	CacheMaxAgeSeconds int `yaml:"cache_max_age_seconds"` // Cache-Control max-age for responses touching "now"
 }
 
 type CoinGeckoConfig struct {
@@ -57,6 +82,133 @@ type BackfillConfig struct {
 	ChunkMinutes int    `yaml:"chunk_minutes"` // size of each backfill window in minutes
 }
 
+type CacheConfig struct {
+	Enabled    bool `yaml:"enabled"`     // Enable in-memory read cache
+	MaxWindows int  `yaml:"max_windows"` // Maximum number of cached quote windows (LRU)
+}
+
+type RateLimitConfig struct {
+	Enabled      bool                     `yaml:"enabled"`        // Enable inbound request throttling
+	APIKeyHeader string                   `yaml:"api_key_header"` // Header carrying the API key (default X-API-Key)
+	Tiers        map[string]RateLimitTier `yaml:"tiers"`          // Named tiers: anonymous, partner, internal, ...
+	APIKeys      []APIKeyConfig           `yaml:"api_keys"`       // Keys defined in config (keys can also be stored in mev.api_keys)
+}
+
+type RateLimitTier struct {
+	RPS       float64 `yaml:"rps"`       // Sustained requests per second
+	Burst     int     `yaml:"burst"`     // Token bucket size (0 = 2 x rps)
+	Unlimited bool    `yaml:"unlimited"` // Do not limit requests of this tier
+}
+
+type APIKeyConfig struct {
+	Name    string `yaml:"name"`     // Client name, used as the rate limit bucket
+	KeyHash string `yaml:"key_hash"` // Hex-encoded SHA-256 of the key (raw keys are never stored)
+	Tier    string `yaml:"tier"`     // Tier name from rate_limit.tiers
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed_origins"`   // Exact origins, one "*" wildcard per entry (https://*.example.com) or "*" for any
+	AllowedMethods   []string `yaml:"allowed_methods"`   // Methods allowed cross-origin (empty = every registered method)
+	AllowedHeaders   []string `yaml:"allowed_headers"`   // Request headers allowed in preflight responses
+	ExposedHeaders   []string `yaml:"exposed_headers"`   // Response headers readable by browsers
+	AllowCredentials bool     `yaml:"allow_credentials"` // Allow cookies / Authorization (the origin is echoed instead of "*")
+	MaxAgeSeconds    int      `yaml:"max_age_seconds"`   // How long browsers may cache preflight responses
+}
+
+type StreamConfig struct {
+	BufferSize       int `yaml:"buffer_size"`       // Quotes buffered per client before it is dropped as too slow
+	HeartbeatSeconds int `yaml:"heartbeat_seconds"` // Interval between heartbeats
+	MaxReplay        int `yaml:"max_replay"`        // Maximum quotes replayed per token when resuming
+	MaxSSEClients    int `yaml:"max_sse_clients"`   // Maximum concurrent Server-Sent Events subscribers
+}
+
+type GraphQLConfig struct {
+	MaxComplexity       int `yaml:"max_complexity"`        // Maximum estimated cost of a query (fields x expected list sizes)
+	MaxDepth            int `yaml:"max_depth"`             // Maximum selection depth
+	MaxPersistedQueries int `yaml:"max_persisted_queries"` // Automatic persisted queries kept in memory (LRU)
+}
+
+type AveragesConfig struct {
+	MaxGapSeconds        int `yaml:"max_gap_seconds"`        // How long a price stays fresh without a new quote (0 = 3 x job.interval_seconds)
+	DefaultWindowSeconds int `yaml:"default_window_seconds"` // Window when none is requested
+	MaxWindowSeconds     int `yaml:"max_window_seconds"`     // Longest window that can be requested
+}
+
+type DailyConfig struct {
+	DefaultMethod string `yaml:"default_method"` // Price of a day when none is requested: close, open or average
+	MaxDays       int    `yaml:"max_days"`       // Most days that can be requested at once
+}
+
+type ConversionConfig struct {
+	MaxQuoteAgeSeconds int `yaml:"max_quote_age_seconds"` // Oldest quote used to convert at a given moment (0 = 3 x job.interval_seconds)
+}
+
+type ValuationsConfig struct {
+	MaxEntries         int `yaml:"max_entries"`           // Most entries valued in one request
+	MaxQuoteAgeSeconds int `yaml:"max_quote_age_seconds"` // Older quotes are flagged as stale (0 = 3 x job.interval_seconds)
+}
+
+type PairsConfig struct {
+	DefaultToleranceSeconds int `yaml:"default_tolerance_seconds"` // How far apart two quotes may be to be aligned, when none is requested
+	MaxToleranceSeconds     int `yaml:"max_tolerance_seconds"`     // Largest tolerance that can be requested
+}
+
+type ProofsConfig struct {
+	Enabled              bool   `yaml:"enabled"`                // Commit daily Merkle roots of the quotes and serve /v1/proofs
+	StartDate            string `yaml:"start_date"`             // First day committed for tokens without commitments (ISO date or RFC3339, default yesterday)
+	CheckIntervalSeconds int    `yaml:"check_interval_seconds"` // How often days due for a commitment are looked for
+}
+
+type OracleConfig struct {
+	Signer          string             `yaml:"signer"`           // local or remote (empty = local when secret_key is set, otherwise oracle endpoints disabled)
+	SecretKey       string             `yaml:"secret_key"`       // Unencrypted edsk/spsk/p2sk key of the local signer (development only)
+	PublicKeyHash   string             `yaml:"public_key_hash"`  // mv1/mv2/mv3 key hash; required by the remote signer, checked against the signer key at startup
+	Remote          RemoteSignerConfig `yaml:"remote"`           // Remote signer settings
+	Decimals        int                `yaml:"decimals"`         // Fixed decimals of the signed nat price
+	DefaultCurrency string             `yaml:"default_currency"` // Currency when none is requested
+	Pusher          OraclePusherConfig `yaml:"pusher"`           // Submits attestations to an oracle contract
+	Harbinger       HarbingerConfig    `yaml:"harbinger"`        // Harbinger-compatible candle feed
+}
+
+type HarbingerConfig struct {
+	Assets        []OracleFeedConfig `yaml:"assets"`         // Candles served, as TOKEN-CURRENCY assets (none = feed disabled)
+	CandleSeconds int                `yaml:"candle_seconds"` // Candle length (default max(60, job.interval_seconds))
+	DataFeed      string             `yaml:"data_feed"`      // Feed name reported by /info
+}
+
+type OraclePusherConfig struct {
+	Enabled          bool               `yaml:"enabled"`           // Run the pusher job (needs an oracle signer whose account is revealed and funded)
+	NodeURL          string             `yaml:"node_url"`          // Mavryk node RPC (e.g., http://localhost:8732)
+	Chain            string             `yaml:"chain"`             // Chain of the node RPC (default main)
+	Contract         string             `yaml:"contract"`          // Oracle contract (KT1...)
+	Entrypoint       string             `yaml:"entrypoint"`        // Entrypoint taking (pair bytes signature)
+	Feeds            []OracleFeedConfig `yaml:"feeds"`             // Prices pushed to the contract
+	DeviationPercent *float64           `yaml:"deviation_percent"` // Push when the price moved at least this much since the last push (0 = every newer price)
+	HeartbeatSeconds int                `yaml:"heartbeat_seconds"` // Push at least this often
+	IntervalSeconds  int                `yaml:"interval_seconds"`  // How often prices are checked (0 = job.interval_seconds)
+	GasLimit         int64              `yaml:"gas_limit"`         // Gas limit of each contract call
+	StorageLimit     int64              `yaml:"storage_limit"`     // Storage limit of each contract call (bytes)
+	FeeMutez         int64              `yaml:"fee_mutez"`         // Fee of each contract call (0 = minimal fee for the gas and size)
+	TimeoutSeconds   int                `yaml:"timeout_seconds"`   // Timeout of node RPC requests
+}
+
+type OracleFeedConfig struct {
+	Token    string `yaml:"token"`
+	Currency string `yaml:"currency"`
+}
+
+type RemoteSignerConfig struct {
+	URL            string `yaml:"url"`             // Base URL of a signer speaking the Tezos remote signer HTTP protocol (e.g., http://signer:6732)
+	TimeoutSeconds int    `yaml:"timeout_seconds"` // Timeout of each signer request
+	Retries        int    `yaml:"retries"`         // Retries of failed requests: network errors, 429 and 5xx (default 2, negative = none)
+}
+
+type BasketConfig struct {
+	BaseValue      float64            `yaml:"base_value"`      // USD value of the basket at its first computed point (default 100)
+	Constituents   map[string]float64 `yaml:"constituents"`    // Token -> weight (normalized to sum to 1)
+	RebalanceDates []string           `yaml:"rebalance_dates"` // ISO dates or RFC3339 times at which units are reset to the weights
+}
+
 type TokenConfig struct {
 	IntervalSeconds     int                 `yaml:"interval_seconds"`       // Collection interval in seconds (0 = use global job.interval_seconds)
 	Enabled             bool                `yaml:"enabled"`                // Enable/disable collection for this token (default: true)
@@ -104,6 +256,20 @@ func overrideWithEnv(config *Config) {
 	if host := os.Getenv("SERVER_HOST"); host != "" {
 		config.Server.Host = host
 	}
+	if timeout := os.Getenv("SERVER_SHUTDOWN_TIMEOUT_SECONDS"); timeout != "" {
+		if val, err := strconv.Atoi(timeout); err == nil {
+			config.Server.ShutdownTimeoutSeconds = val
+		}
+	}
+
+	if enabled := os.Getenv("GRPC_ENABLED"); enabled != "" {
+		if val, err := strconv.ParseBool(enabled); err == nil {
+			config.GRPC.Enabled = val
+		}
+	}
+	if port := os.Getenv("GRPC_PORT"); port != "" {
+		config.GRPC.Port = port
+	}
 
 	if host := os.Getenv("POSTGRES_HOST"); host != "" {
 		config.Database.Host = host
@@ -140,6 +306,11 @@ func overrideWithEnv(config *Config) {
 			config.Job.Enabled = val
 		}
 	}
+	if finality := os.Getenv("JOB_FINALITY_SECONDS"); finality != "" {
+		if val, err := strconv.Atoi(finality); err == nil {
+			config.Job.FinalitySeconds = val
+		}
+	}
 
 	if timeout := os.Getenv("API_TIMEOUT_SECONDS"); timeout != "" {
 		if val, err := strconv.Atoi(timeout); err == nil {
@@ -151,6 +322,11 @@ func overrideWithEnv(config *Config) {
 			config.API.RateLimitRPS = val
 		}
 	}
+	if maxAge := os.Getenv("API_CACHE_MAX_AGE_SECONDS"); maxAge != "" {
+		if val, err := strconv.Atoi(maxAge); err == nil {
+			config.API.CacheMaxAgeSeconds = val
+		}
+	}
 
 	if apiKey := os.Getenv("COINGECKO_API_KEY"); apiKey != "" {
 		config.CoinGecko.APIKey = apiKey
@@ -159,6 +335,36 @@ func overrideWithEnv(config *Config) {
 		config.CoinGecko.BaseURL = baseURL
 	}
 
+	if signer := os.Getenv("ORACLE_SIGNER"); signer != "" {
+		config.Oracle.Signer = signer
+	}
+	if secretKey := os.Getenv("ORACLE_SECRET_KEY"); secretKey != "" {
+		config.Oracle.SecretKey = secretKey
+	}
+	if keyHash := os.Getenv("ORACLE_PUBLIC_KEY_HASH"); keyHash != "" {
+		config.Oracle.PublicKeyHash = keyHash
+	}
+	if url := os.Getenv("ORACLE_REMOTE_URL"); url != "" {
+		config.Oracle.Remote.URL = url
+	}
+	if enabled := os.Getenv("ORACLE_PUSHER_ENABLED"); enabled != "" {
+		if val, err := strconv.ParseBool(enabled); err == nil {
+			config.Oracle.Pusher.Enabled = val
+		}
+	}
+	if nodeURL := os.Getenv("ORACLE_NODE_URL"); nodeURL != "" {
+		config.Oracle.Pusher.NodeURL = nodeURL
+	}
+	if contract := os.Getenv("ORACLE_CONTRACT"); contract != "" {
+		config.Oracle.Pusher.Contract = contract
+	}
+
+	if enabled := os.Getenv("PROOFS_ENABLED"); enabled != "" {
+		if val, err := strconv.ParseBool(enabled); err == nil {
+			config.Proofs.Enabled = val
+		}
+	}
+
 	if enabled := os.Getenv("BACKFILL_ENABLED"); enabled != "" {
 		if val, err := strconv.ParseBool(enabled); err == nil {
 			config.Backfill.Enabled = val
@@ -177,6 +383,38 @@ func overrideWithEnv(config *Config) {
 			config.Backfill.ChunkMinutes = val
 		}
 	}
+
+	if enabled := os.Getenv("RATE_LIMIT_ENABLED"); enabled != "" {
+		if val, err := strconv.ParseBool(enabled); err == nil {
+			config.RateLimit.Enabled = val
+		}
+	}
+
+	if maxClients := os.Getenv("STREAM_MAX_SSE_CLIENTS"); maxClients != "" {
+		if val, err := strconv.Atoi(maxClients); err == nil {
+			config.Stream.MaxSSEClients = val
+		}
+	}
+
+	if origins := os.Getenv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		config.CORS.AllowedOrigins = splitList(origins)
+	}
+	if credentials := os.Getenv("CORS_ALLOW_CREDENTIALS"); credentials != "" {
+		if val, err := strconv.ParseBool(credentials); err == nil {
+			config.CORS.AllowCredentials = val
+		}
+	}
+
+	if enabled := os.Getenv("CACHE_ENABLED"); enabled != "" {
+		if val, err := strconv.ParseBool(enabled); err == nil {
+			config.Cache.Enabled = val
+		}
+	}
+	if maxWindows := os.Getenv("CACHE_MAX_WINDOWS"); maxWindows != "" {
+		if val, err := strconv.Atoi(maxWindows); err == nil {
+			config.Cache.MaxWindows = val
+		}
+	}
 }
 
 func setDefaults(config *Config) {
@@ -186,6 +424,13 @@ func setDefaults(config *Config) {
 	if config.Server.Host == "" {
 		config.Server.Host = "0.0.0.0"
 	}
+	if config.Server.ShutdownTimeoutSeconds == 0 {
+		config.Server.ShutdownTimeoutSeconds = 30
+	}
+
+	if config.GRPC.Port == "" {
+		config.GRPC.Port = "3011"
+	}
 
 	if config.Database.Host == "" {
 		config.Database.Host = "localhost"
@@ -209,6 +454,9 @@ func setDefaults(config *Config) {
 	if config.Job.IntervalSeconds == 0 {
 		config.Job.IntervalSeconds = 60 // 1 minute
 	}
+	if config.Job.FinalitySeconds == 0 {
+		config.Job.FinalitySeconds = 600 // 10 minutes
+	}
 
 	if config.API.TimeoutSeconds == 0 {
 		config.API.TimeoutSeconds = 30
@@ -216,6 +464,9 @@ func setDefaults(config *Config) {
 	if config.API.RateLimitRPS == 0 {
 		config.API.RateLimitRPS = 100
 	}
+	if config.API.CacheMaxAgeSeconds == 0 {
+		config.API.CacheMaxAgeSeconds = 30
+	}
 
 	if config.CoinGecko.BaseURL == "" {
 		config.CoinGecko.BaseURL = "https://api.coingecko.com/api/v3"
@@ -230,12 +481,256 @@ func setDefaults(config *Config) {
 	if config.Backfill.ChunkMinutes == 0 {
 		config.Backfill.ChunkMinutes = 5
 	}
+
+	if config.Cache.MaxWindows == 0 {
+		config.Cache.MaxWindows = 1000
+	}
+
+	// Rate limit defaults: anonymous clients get api.rate_limit_rps per IP,
+	// partners ten times that, internal clients are not limited
+	if config.RateLimit.APIKeyHeader == "" {
+		config.RateLimit.APIKeyHeader = "X-API-Key"
+	}
+	if config.RateLimit.Tiers == nil {
+		config.RateLimit.Tiers = make(map[string]RateLimitTier)
+	}
+	if _, ok := config.RateLimit.Tiers["anonymous"]; !ok {
+		config.RateLimit.Tiers["anonymous"] = RateLimitTier{RPS: float64(config.API.RateLimitRPS)}
+	}
+	if _, ok := config.RateLimit.Tiers["partner"]; !ok {
+		config.RateLimit.Tiers["partner"] = RateLimitTier{RPS: float64(config.API.RateLimitRPS * 10)}
+	}
+	if _, ok := config.RateLimit.Tiers["internal"]; !ok {
+		config.RateLimit.Tiers["internal"] = RateLimitTier{Unlimited: true}
+	}
+	for name, tier := range config.RateLimit.Tiers {
+		if tier.Burst == 0 {
+			tier.Burst = int(tier.RPS * 2)
+			if tier.Burst < 1 {
+				tier.Burst = 1
+			}
+			config.RateLimit.Tiers[name] = tier
+		}
+	}
+
+	if config.Stream.BufferSize == 0 {
+		config.Stream.BufferSize = 256
+	}
+	if config.Stream.HeartbeatSeconds == 0 {
+		config.Stream.HeartbeatSeconds = 30
+	}
+	if config.Stream.MaxReplay == 0 {
+		config.Stream.MaxReplay = 1000
+	}
+	if config.Stream.MaxSSEClients == 0 {
+		config.Stream.MaxSSEClients = 1000
+	}
+
+	if config.GraphQL.MaxComplexity == 0 {
+		config.GraphQL.MaxComplexity = 20000
+	}
+	if config.GraphQL.MaxDepth == 0 {
+		config.GraphQL.MaxDepth = 8
+	}
+	if config.GraphQL.MaxPersistedQueries == 0 {
+		config.GraphQL.MaxPersistedQueries = 1000
+	}
+
+	if config.Averages.MaxGapSeconds == 0 {
+		config.Averages.MaxGapSeconds = 3 * config.Job.IntervalSeconds
+	}
+	if config.Averages.DefaultWindowSeconds == 0 {
+		config.Averages.DefaultWindowSeconds = 1800 // 30 minutes
+	}
+	if config.Averages.MaxWindowSeconds == 0 {
+		config.Averages.MaxWindowSeconds = 7 * 24 * 3600 // 7 days
+	}
+
+	if config.Daily.DefaultMethod == "" {
+		config.Daily.DefaultMethod = "close"
+	}
+	if config.Daily.MaxDays == 0 {
+		config.Daily.MaxDays = 1096 // 3 years
+	}
+
+	if config.Conversion.MaxQuoteAgeSeconds == 0 {
+		config.Conversion.MaxQuoteAgeSeconds = 3 * config.Job.IntervalSeconds
+	}
+
+	if config.Valuations.MaxEntries == 0 {
+		config.Valuations.MaxEntries = 10000
+	}
+	if config.Valuations.MaxQuoteAgeSeconds == 0 {
+		config.Valuations.MaxQuoteAgeSeconds = 3 * config.Job.IntervalSeconds
+	}
+
+	if config.Oracle.Signer == "" && config.Oracle.SecretKey != "" {
+		config.Oracle.Signer = "local"
+	}
+	if config.Oracle.Remote.TimeoutSeconds == 0 {
+		config.Oracle.Remote.TimeoutSeconds = 5
+	}
+	if config.Oracle.Remote.Retries == 0 {
+		config.Oracle.Remote.Retries = 2
+	}
+	if config.Oracle.Pusher.Chain == "" {
+		config.Oracle.Pusher.Chain = "main"
+	}
+	if config.Oracle.Pusher.Entrypoint == "" {
+		config.Oracle.Pusher.Entrypoint = "update"
+	}
+	if config.Oracle.Pusher.DeviationPercent == nil {
+		deviation := 0.5
+		config.Oracle.Pusher.DeviationPercent = &deviation
+	}
+	if config.Oracle.Pusher.HeartbeatSeconds == 0 {
+		config.Oracle.Pusher.HeartbeatSeconds = 3600
+	}
+	if config.Oracle.Pusher.IntervalSeconds == 0 {
+		config.Oracle.Pusher.IntervalSeconds = config.Job.IntervalSeconds
+	}
+	if config.Oracle.Pusher.GasLimit == 0 {
+		config.Oracle.Pusher.GasLimit = 20000
+	}
+	if config.Oracle.Pusher.StorageLimit == 0 {
+		config.Oracle.Pusher.StorageLimit = 100
+	}
+	if config.Oracle.Pusher.TimeoutSeconds == 0 {
+		config.Oracle.Pusher.TimeoutSeconds = 10
+	}
+	if config.Oracle.Decimals == 0 {
+		config.Oracle.Decimals = 8
+	}
+	if config.Oracle.DefaultCurrency == "" {
+		config.Oracle.DefaultCurrency = "usd"
+	}
+	if config.Proofs.CheckIntervalSeconds == 0 {
+		config.Proofs.CheckIntervalSeconds = 3600
+	}
+	if config.Oracle.Harbinger.CandleSeconds == 0 {
+		config.Oracle.Harbinger.CandleSeconds = max(60, config.Job.IntervalSeconds)
+	}
+	if config.Oracle.Harbinger.DataFeed == "" {
+		config.Oracle.Harbinger.DataFeed = "Mavryk External Data"
+	}
+
+	if config.Pairs.DefaultToleranceSeconds == 0 {
+		config.Pairs.DefaultToleranceSeconds = 30
+	}
+	if config.Pairs.MaxToleranceSeconds == 0 {
+		config.Pairs.MaxToleranceSeconds = 3600
+	}
+
+	// CORS defaults keep the API open to any origin, as it was before the policy was configurable
+	if len(config.CORS.AllowedOrigins) == 0 {
+		config.CORS.AllowedOrigins = []string{"*"}
+	}
+	if len(config.CORS.AllowedHeaders) == 0 {
+		config.CORS.AllowedHeaders = []string{
+			"Origin", "Content-Type", "Accept", "Authorization",
+			"If-None-Match", "If-Modified-Since", "X-Request-ID", config.RateLimit.APIKeyHeader,
+		}
+	}
+	if len(config.CORS.ExposedHeaders) == 0 {
+		config.CORS.ExposedHeaders = []string{
+			"ETag", "Last-Modified",
+			"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After", "X-Request-ID",
+		}
+	}
+	if config.CORS.MaxAgeSeconds == 0 {
+		config.CORS.MaxAgeSeconds = 600
+	}
+}
+
+// splitList splits a comma-separated environment value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetShutdownTimeout returns how long components may take to stop on shutdown
+func (c *Config) GetShutdownTimeout() time.Duration {
+	return time.Duration(c.Server.ShutdownTimeoutSeconds) * time.Second
 }
 
 func (c *Config) GetJobInterval() time.Duration {
 	return time.Duration(c.Job.IntervalSeconds) * time.Second
 }
 
+// GetAverageMaxGap returns how long a price stays fresh in TWAP/VWAP24h computations
+func (c *Config) GetAverageMaxGap() time.Duration {
+	return time.Duration(c.Averages.MaxGapSeconds) * time.Second
+}
+
+// GetConversionMaxQuoteAge returns the age beyond which a quote is not used to convert at a given moment
+func (c *Config) GetConversionMaxQuoteAge() time.Duration {
+	return time.Duration(c.Conversion.MaxQuoteAgeSeconds) * time.Second
+}
+
+// GetValuationMaxQuoteAge returns the age beyond which a quote used for a valuation is stale
+func (c *Config) GetValuationMaxQuoteAge() time.Duration {
+	return time.Duration(c.Valuations.MaxQuoteAgeSeconds) * time.Second
+}
+
+// GetFinalityHorizon returns how far back from now stored quotes can still change
+func (c *Config) GetFinalityHorizon() time.Duration {
+	return time.Duration(c.Job.FinalitySeconds) * time.Second
+}
+
+// GetCacheMaxAge returns the Cache-Control max-age for responses that can still change
+func (c *Config) GetCacheMaxAge() time.Duration {
+	return time.Duration(c.API.CacheMaxAgeSeconds) * time.Second
+}
+
+// GetRemoteSignerTimeout returns the timeout of a remote signer request
+func (c *Config) GetRemoteSignerTimeout() time.Duration {
+	return time.Duration(c.Oracle.Remote.TimeoutSeconds) * time.Second
+}
+
+// GetPusherInterval returns how often the oracle pusher checks its feeds
+func (c *Config) GetPusherInterval() time.Duration {
+	return time.Duration(c.Oracle.Pusher.IntervalSeconds) * time.Second
+}
+
+// GetPusherDeviationPercent returns the price move that triggers a push; a nil setting means the default
+// (set by setDefaults) and 0 pushes every newer quote
+func (c *Config) GetPusherDeviationPercent() float64 {
+	if c.Oracle.Pusher.DeviationPercent == nil {
+		return 0
+	}
+	return *c.Oracle.Pusher.DeviationPercent
+}
+
+// GetPusherHeartbeat returns the longest time between two pushes of a feed
+func (c *Config) GetPusherHeartbeat() time.Duration {
+	return time.Duration(c.Oracle.Pusher.HeartbeatSeconds) * time.Second
+}
+
+// GetPusherTimeout returns the timeout of a node RPC request
+func (c *Config) GetPusherTimeout() time.Duration {
+	return time.Duration(c.Oracle.Pusher.TimeoutSeconds) * time.Second
+}
+
+// GetProofsCheckInterval returns how often the price committer checks for days to commit
+func (c *Config) GetProofsCheckInterval() time.Duration {
+	return time.Duration(c.Proofs.CheckIntervalSeconds) * time.Second
+}
+
+// GetHarbingerCandle returns the length of the candles signed as Harbinger messages
+func (c *Config) GetHarbingerCandle() time.Duration {
+	return time.Duration(c.Oracle.Harbinger.CandleSeconds) * time.Second
+}
+
+// GetStreamHeartbeat returns the interval between stream heartbeats
+func (c *Config) GetStreamHeartbeat() time.Duration {
+	return time.Duration(c.Stream.HeartbeatSeconds) * time.Second
+}
+
 func (c *Config) GetTokenConfig(tokenName string) TokenConfig {
 	if c.Tokens == nil {
 		c.Tokens = make(map[string]TokenConfig)
@@ -340,3 +835,15 @@ func (c *Config) IsTokenEnabled(tokenName string) bool {
 	tokenCfg := c.GetTokenConfig(tokenName)
 	return tokenCfg.Enabled
 }
+
+// ParseDate parses a date setting given as RFC3339 or as a date (2025-01-01, midnight UTC)
+func ParseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, use RFC3339 or YYYY-MM-DD", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,197 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// complexity estimates the cost of an operation before it runs: every field costs 1 and list
+// fields multiply the cost of their selection by the expected number of items. For "quotes"
+// that is the limit, or the number of points the time range spans at the collection interval.
+type complexity struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	interval  time.Duration
+}
+
+// estimate returns the cost and depth of the selected operation. The document must be valid.
+func estimate(doc *ast.Document, operationName string, variables map[string]interface{}, interval time.Duration) (int, int, error) {
+	c := &complexity{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: make(map[string]interface{}),
+		interval:  interval,
+	}
+
+	var operations []*ast.OperationDefinition
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			operations = append(operations, definition)
+			if definition.Name != nil && definition.Name.Value == operationName {
+				operation = definition
+			}
+		}
+	}
+	if operationName == "" {
+		if len(operations) != 1 {
+			return 0, 0, fmt.Errorf("Must provide operation name if query contains multiple operations")
+		}
+		operation = operations[0]
+	}
+	if operation == nil {
+		return 0, 0, fmt.Errorf("Unknown operation named %q", operationName)
+	}
+
+	// Defaults of omitted variables count as well, they are what the resolvers will see
+	for _, definition := range operation.VariableDefinitions {
+		if definition.DefaultValue != nil {
+			c.variables[definition.Variable.Name.Value] = c.value(definition.DefaultValue)
+		}
+	}
+	for name, value := range variables {
+		c.variables[name] = value
+	}
+
+	cost, depth := c.selectionSet(operation.SelectionSet)
+	return cost, depth, nil
+}
+
+func (c *complexity) selectionSet(set *ast.SelectionSet) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	cost, depth := 0, 0
+	for _, selection := range set.Selections {
+		var selectionCost, selectionDepth int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			// Introspection is served from the schema and never reaches the database
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			childCost, childDepth := c.selectionSet(selection.SelectionSet)
+			selectionCost = saturatingAdd(1, saturatingMul(c.multiplier(selection), childCost))
+			selectionDepth = childDepth + 1
+		case *ast.InlineFragment:
+			selectionCost, selectionDepth = c.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := c.fragments[selection.Name.Value]; ok {
+				selectionCost, selectionDepth = c.selectionSet(fragment.SelectionSet)
+			}
+		}
+		cost = saturatingAdd(cost, selectionCost)
+		if selectionDepth > depth {
+			depth = selectionDepth
+		}
+	}
+	return cost, depth
+}
+
+// multiplier returns the expected number of items of a field
+func (c *complexity) multiplier(field *ast.Field) int {
+	switch field.Name.Value {
+	case "latest":
+		if tokens, ok := c.argument(field, "tokens").([]interface{}); ok {
+			return len(tokens)
+		}
+		return len(quotes.GetSupportedTokenNames())
+	case "prices":
+		if currencies, ok := c.argument(field, "currencies").([]interface{}); ok {
+			return len(currencies)
+		}
+		return len(quotes.GetSupportedCurrencies())
+	case "quotes":
+		return c.quotesMultiplier(field)
+	default:
+		return 1
+	}
+}
+
+func (c *complexity) quotesMultiplier(field *ast.Field) int {
+	limit, _ := toInt(c.argument(field, "limit"))
+
+	// Same parsing and defaults as the resolver
+	from, to, ranged := timeRange(c.argument(field, "from"), c.argument(field, "to"), time.Now())
+	if !ranged {
+		if limit > 0 {
+			return limit
+		}
+		return defaultLimit
+	}
+
+	points := 1
+	if c.interval > 0 && to.After(from) {
+		points = int(math.Min(float64(to.Sub(from)/c.interval)+1, math.MaxInt32))
+	}
+	if limit > 0 && limit < points {
+		return limit
+	}
+	return points
+}
+
+func (c *complexity) argument(field *ast.Field, name string) interface{} {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value == name {
+			return c.value(argument.Value)
+		}
+	}
+	return nil
+}
+
+func (c *complexity) value(value ast.Value) interface{} {
+	switch value := value.(type) {
+	case *ast.Variable:
+		return c.variables[value.Name.Value]
+	case *ast.IntValue:
+		n, _ := strconv.Atoi(value.Value)
+		return n
+	case *ast.StringValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	case *ast.ListValue:
+		list := make([]interface{}, len(value.Values))
+		for i, item := range value.Values {
+			list[i] = c.value(item)
+		}
+		return list
+	default:
+		return nil
+	}
+}
+
+// toInt converts literal and JSON variable numbers
+func toInt(value interface{}) (int, bool) {
+	switch value := value.(type) {
+	case int:
+		return value, true
+	case float64:
+		return int(math.Min(value, math.MaxInt32)), true
+	default:
+		return 0, false
+	}
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if a != 0 && b > math.MaxInt32/a {
+		return math.MaxInt32
+	}
+	return a * b
+}
@@ -0,0 +1,32 @@
+package graphql
+
+import (
+	"log"
+	"net/http"
+	"quotes/internal/core/api/http/apierror"
+)
+
+// resolverError is a resolver failure as clients see it: the message of the domain error and the code the
+// HTTP API answers it with, in extensions.code. Causes (e.g. database errors) are only logged.
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+// Extensions implements gqlerrors.ExtendedError
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// domainError maps an error returned by an application action to a resolverError
+func domainError(err error) error {
+	apiErr := apierror.From(err)
+	if apiErr.Status >= http.StatusInternalServerError {
+		log.Printf("GraphQL resolver failed: %v", err)
+	}
+	return &resolverError{message: apiErr.Message, code: apiErr.Code}
+}
@@ -0,0 +1,186 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/application/quotes/get_by_token"
+	"quotes/internal/core/application/quotes/get_count"
+	"quotes/internal/core/application/quotes/get_latest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// maxBodyBytes bounds POST bodies; queries are limited by complexity only once parsed
+const maxBodyBytes = 1 << 20
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    extensions             `json:"extensions"`
+}
+
+type extensions struct {
+	PersistedQuery *struct {
+		Version    int    `json:"version"`
+		SHA256Hash string `json:"sha256Hash"`
+	} `json:"persistedQuery"`
+}
+
+type Handler struct {
+	schema    graphql.Schema
+	config    *config.Config
+	persisted *persistedQueries
+}
+
+func New(cfg *config.Config, getLatest *get_latest.Action, getByToken *get_by_token.Action, getCount *get_count.Action) (*Handler, error) {
+	schema, err := newSchema(&resolver{getLatest: getLatest, getByToken: getByToken, getCount: getCount})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+
+	return &Handler{
+		schema:    schema,
+		config:    cfg,
+		persisted: newPersistedQueries(cfg.GraphQL.MaxPersistedQueries),
+	}, nil
+}
+
+// GraphQL godoc
+// @Summary      GraphQL endpoint
+// @Description  Executes a GraphQL query ({"query": "...", "variables": {...}, "operationName": "..."}) against the quotes schema:
+// @Description  latest(tokens), quotes(token, from, to, limit), count(token), tokens. GET takes the same fields as query parameters.
+// @Description  Queries whose estimated cost exceeds graphql.max_complexity or whose depth exceeds graphql.max_depth are rejected.
+// @Description  Automatic persisted queries are supported through extensions.persistedQuery.sha256Hash.
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "GraphQL request"
+// @Success      200      {object}  map[string]interface{}  "GraphQL result (data and errors)"
+// @Failure      400      {object}  map[string]interface{}  "Invalid, too deep or too complex query"
+// @Failure      413      {object}  map[string]interface{}  "Request body larger than 1 MiB"
+// @Router       /graphql [post]
+func (h *Handler) Handle(c *gin.Context) {
+	req, err := parseRequest(c)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondErrors(c, http.StatusRequestEntityTooLarge, codedError(
+				fmt.Sprintf("Request body exceeds %d bytes", tooLarge.Limit), "REQUEST_TOO_LARGE"))
+			return
+		}
+		respondErrors(c, http.StatusBadRequest, gqlerrors.NewFormattedError(err.Error()))
+		return
+	}
+
+	query := req.Query
+	if pq := req.Extensions.PersistedQuery; pq != nil {
+		if pq.Version != 1 {
+			respondErrors(c, http.StatusBadRequest, gqlerrors.NewFormattedError("Unsupported persisted query version"))
+			return
+		}
+		if query == "" {
+			stored, ok := h.persisted.get(pq.SHA256Hash)
+			if !ok {
+				// Clients retry with the full query, which registers it
+				respondErrors(c, http.StatusOK, codedError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND"))
+				return
+			}
+			query = stored
+		} else if !h.persisted.register(pq.SHA256Hash, query) {
+			respondErrors(c, http.StatusBadRequest, gqlerrors.NewFormattedError("Provided sha256Hash does not match query"))
+			return
+		}
+	}
+	if query == "" {
+		respondErrors(c, http.StatusBadRequest, gqlerrors.NewFormattedError("Must provide query string"))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		respondErrors(c, http.StatusBadRequest, gqlerrors.FormatErrors(err)...)
+		return
+	}
+
+	if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		respondErrors(c, http.StatusBadRequest, validation.Errors...)
+		return
+	}
+
+	// Reject expensive queries before they reach the database
+	cost, depth, err := estimate(doc, req.OperationName, req.Variables, h.config.GetJobInterval())
+	if err != nil {
+		respondErrors(c, http.StatusBadRequest, gqlerrors.NewFormattedError(err.Error()))
+		return
+	}
+	if depth > h.config.GraphQL.MaxDepth {
+		respondErrors(c, http.StatusBadRequest, codedError(
+			fmt.Sprintf("Query depth %d exceeds the maximum of %d", depth, h.config.GraphQL.MaxDepth), "QUERY_TOO_DEEP"))
+		return
+	}
+	if cost > h.config.GraphQL.MaxComplexity {
+		respondErrors(c, http.StatusBadRequest, codedError(
+			fmt.Sprintf("Query complexity %d exceeds the maximum of %d, narrow the time ranges or lower the limits", cost, h.config.GraphQL.MaxComplexity), "QUERY_TOO_COMPLEX"))
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       c.Request.Context(),
+	})
+	result.Extensions = map[string]interface{}{"complexity": cost}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseRequest reads a GraphQL request from the JSON body (POST) or the query string (GET)
+func parseRequest(c *gin.Context) (request, error) {
+	var req request
+
+	if c.Request.Method != http.MethodGet {
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			return req, fmt.Errorf("Invalid JSON body: %w", err)
+		}
+		return req, nil
+	}
+
+	req.Query = c.Query("query")
+	req.OperationName = c.Query("operationName")
+	if variables := c.Query("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+			return req, fmt.Errorf("Invalid 'variables' parameter: %v", err)
+		}
+	}
+	if ext := c.Query("extensions"); ext != "" {
+		if err := json.Unmarshal([]byte(ext), &req.Extensions); err != nil {
+			return req, fmt.Errorf("Invalid 'extensions' parameter: %v", err)
+		}
+	}
+	return req, nil
+}
+
+func codedError(message, code string) gqlerrors.FormattedError {
+	err := gqlerrors.NewFormattedError(message)
+	err.Extensions = map[string]interface{}{"code": code}
+	return err
+}
+
+func respondErrors(c *gin.Context, status int, errs ...gqlerrors.FormattedError) {
+	c.JSON(status, gin.H{
+		"errors": errs,
+	})
+}
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+// persistedQueries implements automatic persisted queries: clients send the SHA-256 of a
+// query instead of its text and register the text once when the hash is unknown.
+// Queries are kept in an LRU bounded by graphql.max_persisted_queries.
+type persistedQueries struct {
+	max int
+
+	mu      sync.Mutex
+	queries map[string]*list.Element
+	lru     *list.List
+}
+
+type persistedQuery struct {
+	hash  string
+	query string
+}
+
+func newPersistedQueries(max int) *persistedQueries {
+	return &persistedQueries{
+		max:     max,
+		queries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (p *persistedQueries) get(hash string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	el, ok := p.queries[strings.ToLower(hash)]
+	if !ok {
+		return "", false
+	}
+	p.lru.MoveToFront(el)
+	return el.Value.(*persistedQuery).query, true
+}
+
+// register stores a query under its hash, reporting false when the hash doesn't match the query
+func (p *persistedQueries) register(hash, query string) bool {
+	sum := sha256.Sum256([]byte(query))
+	hash = strings.ToLower(hash)
+	if hex.EncodeToString(sum[:]) != hash {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if el, ok := p.queries[hash]; ok {
+		p.lru.MoveToFront(el)
+		return true
+	}
+
+	p.queries[hash] = p.lru.PushFront(&persistedQuery{hash: hash, query: query})
+	for p.lru.Len() > p.max {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.queries, oldest.Value.(*persistedQuery).hash)
+	}
+	return true
+}
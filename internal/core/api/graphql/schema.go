@@ -0,0 +1,332 @@
+package graphql
+
+import (
+	"errors"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/get_by_token"
+	"quotes/internal/core/application/quotes/get_count"
+	"quotes/internal/core/application/quotes/get_latest"
+	"quotes/internal/core/domain/quotes"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// defaultLimit matches the HTTP API: latest 100 quotes when no time range is given
+const defaultLimit = 100
+
+// resolver answers queries with the existing application actions
+type resolver struct {
+	getLatest  *get_latest.Action
+	getByToken *get_by_token.Action
+	getCount   *get_count.Action
+}
+
+// tokenQuote is the latest quote of a token
+type tokenQuote struct {
+	Token string
+	Quote quotes.Quote
+}
+
+// price is a quote value in one currency
+type price struct {
+	Currency quotes.Currency
+	Value    float64
+}
+
+func newSchema(r *resolver) (graphql.Schema, error) {
+	currencyValues := graphql.EnumValueConfigMap{}
+	for _, currency := range quotes.GetSupportedCurrencies() {
+		currencyValues[strings.ToUpper(string(currency))] = &graphql.EnumValueConfig{Value: currency}
+	}
+	currencyEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:   "Currency",
+		Values: currencyValues,
+	})
+
+	// Time arguments take the formats of the HTTP time parameters. Values are kept as given and parsed
+	// by timeRange, so that a date as "to" covers the whole day like in GET /:token.
+	timeInput := graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "TimeInput",
+		Description: "A time: " + timeparam.Formats + ". Times without an offset are UTC.",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: parseTimeInput,
+		ParseLiteral: func(value ast.Value) interface{} {
+			switch value := value.(type) {
+			case *ast.StringValue:
+				return parseTimeInput(value.Value)
+			case *ast.IntValue:
+				return parseTimeInput(value.Value)
+			default:
+				return nil
+			}
+		},
+	})
+
+	priceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Price",
+		Fields: graphql.Fields{
+			"currency": &graphql.Field{
+				Type: graphql.NewNonNull(currencyEnum),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(price).Currency, nil
+				},
+			},
+			"value": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Float),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(price).Value, nil
+				},
+			},
+		},
+	})
+
+	quoteFields := graphql.Fields{
+		"timestamp": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(quotes.Quote).Timestamp.UTC(), nil
+			},
+		},
+		"price": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Float),
+			Description: "Price in the given currency",
+			Args: graphql.FieldConfigArgument{
+				"currency": &graphql.ArgumentConfig{Type: graphql.NewNonNull(currencyEnum)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(quotes.Quote).Price(p.Args["currency"].(quotes.Currency)), nil
+			},
+		},
+		"prices": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(priceType))),
+			Description: "Prices in the given currencies (default: all)",
+			Args: graphql.FieldConfigArgument{
+				"currencies": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(currencyEnum))},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				quote := p.Source.(quotes.Quote)
+				currencies := quotes.GetSupportedCurrencies()
+				if list, ok := p.Args["currencies"].([]interface{}); ok {
+					currencies = make([]quotes.Currency, len(list))
+					for i, currency := range list {
+						currencies[i] = currency.(quotes.Currency)
+					}
+				}
+				prices := make([]price, len(currencies))
+				for i, currency := range currencies {
+					prices[i] = price{Currency: currency, Value: quote.Price(currency)}
+				}
+				return prices, nil
+			},
+		},
+	}
+	for _, currency := range quotes.GetSupportedCurrencies() {
+		currency := currency
+		quoteFields[string(currency)] = &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Float),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(quotes.Quote).Price(currency), nil
+			},
+		}
+	}
+	quoteType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Quote",
+		Fields: quoteFields,
+	})
+
+	tokenQuoteType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TokenQuote",
+		Fields: graphql.Fields{
+			"token": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(tokenQuote).Token, nil
+				},
+			},
+			"quote": &graphql.Field{
+				Type: graphql.NewNonNull(quoteType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(tokenQuote).Quote, nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"latest": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tokenQuoteType))),
+				Description: "Latest quote of each token (default: all supported tokens that have quotes)",
+				Args: graphql.FieldConfigArgument{
+					"tokens": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				},
+				Resolve: r.latest,
+			},
+			"quotes": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(quoteType))),
+				Description: "Quotes of a token in a time range, or the latest ones when no range is given (same defaults as GET /:token)",
+				Args: graphql.FieldConfigArgument{
+					"token": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"from":  &graphql.ArgumentConfig{Type: timeInput},
+					"to":    &graphql.ArgumentConfig{Type: timeInput},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.quotes,
+			},
+			"count": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Number of stored quotes of a token",
+				Args: graphql.FieldConfigArgument{
+					"token": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: r.count,
+			},
+			"tokens": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Description: "Supported tokens",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return supportedTokens(), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// latest returns the last quote of the listed tokens, or of every supported token that has one
+// when no tokens are listed (e.g. a basket without points yet is left out)
+func (r *resolver) latest(p graphql.ResolveParams) (interface{}, error) {
+	tokens := supportedTokens()
+	list, listed := p.Args["tokens"].([]interface{})
+	if listed {
+		tokens = make([]string, len(list))
+		for i, token := range list {
+			tokens[i] = token.(string)
+		}
+	}
+
+	result := make([]tokenQuote, 0, len(tokens))
+	for _, token := range tokens {
+		tokenName, err := validateToken(token)
+		if err != nil {
+			return nil, err
+		}
+		quote, err := r.getLatest.Execute(p.Context, tokenName)
+		if !listed && errors.Is(err, quotes.ErrNoData) {
+			continue
+		}
+		if err != nil {
+			return nil, domainError(err)
+		}
+		result = append(result, tokenQuote{Token: tokenName, Quote: quote})
+	}
+	return result, nil
+}
+
+func (r *resolver) quotes(p graphql.ResolveParams) (interface{}, error) {
+	tokenName, err := validateToken(p.Args["token"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	limit, _ := p.Args["limit"].(int)
+	if limit < 0 {
+		return nil, domainError(apierror.InvalidParameter("invalid 'limit' argument, must be a positive integer"))
+	}
+
+	from, to, ranged := timeRange(p.Args["from"], p.Args["to"], time.Now())
+	if ranged && from.After(to) {
+		return nil, domainError(quotes.InvalidRange("invalid time range: 'from' must be before 'to'"))
+	}
+	if !ranged && limit == 0 {
+		limit = defaultLimit
+	}
+
+	quotesList, err := r.getByToken.Execute(p.Context, tokenName, from, to, limit)
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return quotesList, nil
+}
+
+func (r *resolver) count(p graphql.ResolveParams) (interface{}, error) {
+	tokenName, err := validateToken(p.Args["token"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.getCount.Execute(p.Context, tokenName)
+	if err != nil {
+		return nil, domainError(err)
+	}
+	return count, nil
+}
+
+// timeRange applies the GET /:token defaults: "to" is now and "from" is timeparam.DefaultSpan before "to".
+// Invalid values are rejected by the TimeInput scalar before this runs, and are ignored here.
+func timeRange(fromValue, toValue interface{}, now time.Time) (time.Time, time.Time, bool) {
+	fromString, hasFrom := timeArgument(fromValue)
+	toString, hasTo := timeArgument(toValue)
+	if !hasFrom && !hasTo {
+		return time.Time{}, time.Time{}, false
+	}
+
+	to := now.UTC()
+	if parsed, err := timeparam.ParseEnd(toString, now, time.UTC); hasTo && err == nil {
+		to = parsed
+	}
+	from := to.Add(-timeparam.DefaultSpan)
+	if parsed, err := timeparam.Parse(fromString, now, time.UTC); hasFrom && err == nil {
+		from = parsed
+	}
+	return from, to, true
+}
+
+// timeArgument returns a time argument as a string; integers are unix timestamps
+func timeArgument(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case int:
+		return strconv.Itoa(value), true
+	default:
+		return "", false
+	}
+}
+
+// parseTimeInput keeps a valid time argument as a string, nil rejects it
+func parseTimeInput(value interface{}) interface{} {
+	text, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	if _, err := timeparam.Parse(text, time.Now(), time.UTC); err != nil {
+		return nil
+	}
+	return text
+}
+
+// supportedTokens returns the supported token names in a stable order
+func supportedTokens() []string {
+	tokens := quotes.GetSupportedTokenNames()
+	sort.Strings(tokens)
+	return tokens
+}
+
+func validateToken(token string) (string, error) {
+	tokenName := strings.ToLower(token)
+	if !quotes.IsTokenSupported(tokenName) {
+		return "", domainError(quotes.TokenNotFound(tokenName))
+	}
+	return tokenName, nil
+}
@@ -0,0 +1,230 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	quotesv1 "quotes/api/proto/quotes/v1"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/application/quotes/get_by_token"
+	"quotes/internal/core/application/quotes/get_count"
+	"quotes/internal/core/application/quotes/get_latest"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/pubsub"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultLimit matches the HTTP API: latest 100 quotes when no time range is given
+const defaultLimit = 100
+
+type quotesService struct {
+	quotesv1.UnimplementedQuotesServiceServer
+
+	config     *config.Config
+	getLatest  *get_latest.Action
+	getByToken *get_by_token.Action
+	getCount   *get_count.Action
+	hub        *pubsub.Hub
+
+	// stopping is closed on shutdown to end subscriptions, which would otherwise block GracefulStop
+	stopping chan struct{}
+}
+
+func newQuotesService(
+	cfg *config.Config,
+	getLatest *get_latest.Action,
+	getByToken *get_by_token.Action,
+	getCount *get_count.Action,
+	hub *pubsub.Hub,
+) *quotesService {
+	return &quotesService{
+		config:     cfg,
+		getLatest:  getLatest,
+		getByToken: getByToken,
+		getCount:   getCount,
+		hub:        hub,
+		stopping:   make(chan struct{}),
+	}
+}
+
+func (s *quotesService) GetLatest(ctx context.Context, req *quotesv1.GetLatestRequest) (*quotesv1.Quote, error) {
+	tokenName, err := validateToken(req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.getLatest.Execute(ctx, tokenName)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return format.ToProto(quote), nil
+}
+
+func (s *quotesService) GetByToken(ctx context.Context, req *quotesv1.GetByTokenRequest) (*quotesv1.QuoteList, error) {
+	tokenName, err := validateToken(req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be a positive integer")
+	}
+	limit := int(req.GetLimit())
+
+	// Same defaults as GET /:token
+	var from, to time.Time
+	if req.From != nil || req.To != nil {
+		now := time.Now()
+		from, to = now.Add(-24*time.Hour), now
+		if req.From != nil {
+			from = req.From.AsTime()
+		}
+		if req.To != nil {
+			to = req.To.AsTime()
+		}
+		if from.After(to) {
+			return nil, status.Error(codes.InvalidArgument, "invalid time range: 'from' must be before 'to'")
+		}
+	} else if limit == 0 {
+		limit = defaultLimit
+	}
+
+	quotesList, err := s.getByToken.Execute(ctx, tokenName, from, to, limit)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return format.ToProtoList(quotesList), nil
+}
+
+func (s *quotesService) GetCount(ctx context.Context, req *quotesv1.GetCountRequest) (*quotesv1.GetCountResponse, error) {
+	tokenName, err := validateToken(req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.getCount.Execute(ctx, tokenName)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &quotesv1.GetCountResponse{Count: count}, nil
+}
+
+func (s *quotesService) SubscribeQuotes(req *quotesv1.SubscribeQuotesRequest, stream quotesv1.QuotesService_SubscribeQuotesServer) error {
+	if len(req.GetTokens()) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one token is required")
+	}
+	tokens := make([]string, 0, len(req.GetTokens()))
+	for _, token := range req.GetTokens() {
+		tokenName, err := validateToken(token)
+		if err != nil {
+			return err
+		}
+		tokens = append(tokens, tokenName)
+	}
+
+	// Subscribe before replaying so quotes saved during the replay are not missed
+	sub := s.hub.Subscribe(tokens, s.config.Stream.BufferSize)
+	defer sub.Close()
+
+	lastSent := make(map[string]time.Time)
+	send := func(tokenName string, quote quotes.Quote) error {
+		if !quote.Timestamp.After(lastSent[tokenName]) {
+			return nil
+		}
+		if err := stream.Send(&quotesv1.QuoteEvent{Token: tokenName, Quote: format.ToProto(quote)}); err != nil {
+			return err
+		}
+		lastSent[tokenName] = quote.Timestamp
+		return nil
+	}
+
+	if req.Since != nil {
+		since := req.Since.AsTime()
+		for _, tokenName := range tokens {
+			lastSent[tokenName] = since
+			quotesList, err := s.getByToken.Execute(stream.Context(), tokenName, since, time.Now(), s.config.Stream.MaxReplay+1)
+			if err != nil {
+				log.Printf("Error replaying quotes for %s: %v", tokenName, err)
+				return statusError(err)
+			}
+
+			truncated := len(quotesList) > s.config.Stream.MaxReplay
+			if truncated {
+				quotesList = quotesList[:s.config.Stream.MaxReplay]
+			}
+			for _, quote := range quotesList {
+				if err := send(tokenName, quote); err != nil {
+					return err
+				}
+			}
+
+			if truncated {
+				// The client has to fetch the gap with GetByToken
+				if err := stream.Send(&quotesv1.QuoteEvent{Token: tokenName, ReplayTruncated: true}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.stopping:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event, ok := <-sub.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "client too slow, resubscribe with since")
+			}
+			if err := send(event.TokenName, event.Quote); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// stop ends all subscriptions
+func (s *quotesService) stop() {
+	close(s.stopping)
+}
+
+func validateToken(token string) (string, error) {
+	tokenName := strings.ToLower(token)
+	if tokenName == "" {
+		return "", status.Error(codes.InvalidArgument, "token is required")
+	}
+	if !quotes.IsTokenSupported(tokenName) {
+		return "", status.Errorf(codes.NotFound, "token '%s' is not supported", tokenName)
+	}
+	return tokenName, nil
+}
+
+// statusError maps domain errors to gRPC status codes; causes of internal errors are not sent
+func statusError(err error) error {
+	message := "internal error"
+	var domainErr *quotes.Error
+	if errors.As(err, &domainErr) {
+		message = domainErr.Message
+	}
+
+	switch {
+	case errors.Is(err, quotes.ErrTokenNotFound), errors.Is(err, quotes.ErrNoData):
+		return status.Error(codes.NotFound, message)
+	case errors.Is(err, quotes.ErrInvalidRange):
+		return status.Error(codes.InvalidArgument, message)
+	case errors.Is(err, quotes.ErrUpstreamUnavailable):
+		return status.Error(codes.Unavailable, message)
+	default:
+		log.Printf("gRPC request failed: %v", err)
+		return status.Error(codes.Internal, message)
+	}
+}
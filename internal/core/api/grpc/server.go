@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"net"
+	quotesv1 "quotes/api/proto/quotes/v1"
+	"quotes/internal/config"
+	appGetByToken "quotes/internal/core/application/quotes/get_by_token"
+	appGetCount "quotes/internal/core/application/quotes/get_count"
+	appGetLatest "quotes/internal/core/application/quotes/get_latest"
+	"quotes/internal/core/infrastructure/cache"
+	"quotes/internal/core/infrastructure/pubsub"
+	"quotes/internal/core/infrastructure/storage/repositories"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm"
+)
+
+// Server serves the gRPC API next to the HTTP one, on top of the same application actions
+type Server struct {
+	config  *config.Config
+	server  *grpc.Server
+	health  *health.Server
+	service *quotesService
+}
+
+func NewServer(cfg *config.Config, db *gorm.DB, quoteCache *cache.QuoteCache, hub *pubsub.Hub) *Server {
+	// Create repositories
+	quoteRepo := repositories.NewQuoteRepository(db)
+
+	// Create application actions (reads of quotes go through the cache)
+	getLatestAction := appGetLatest.New(quoteCache)
+	getCountAction := appGetCount.New(quoteRepo)
+	getByTokenAction := appGetByToken.New(quoteCache)
+
+	service := newQuotesService(cfg, getLatestAction, getByTokenAction, getCountAction, hub)
+
+	server := grpc.NewServer()
+	quotesv1.RegisterQuotesServiceServer(server, service)
+
+	// Standard health checking protocol; "" is the overall server status
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(quotesv1.QuotesService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	// Lets grpcurl and similar tools discover the services
+	reflection.Register(server)
+
+	return &Server{
+		config:  cfg,
+		server:  server,
+		health:  healthServer,
+		service: service,
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	addr := s.config.Server.Host + ":" + s.config.GRPC.Port
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Starting gRPC server on %s", addr)
+	return s.server.Serve(listener)
+}
+
+// Stop marks the server as not serving, ends subscriptions and waits for pending RPCs to finish.
+// RPCs still running when ctx expires are cancelled.
+func (s *Server) Stop(ctx context.Context) error {
+	s.health.Shutdown()
+	s.service.stop()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,96 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+	"quotes/internal/core/domain/quotes"
+)
+
+// Machine-readable error codes; they are part of the API and must not change
+const (
+	CodeInvalidParameter    = "INVALID_PARAMETER"
+	CodeInvalidRange        = "INVALID_RANGE"
+	CodeTokenNotFound       = "TOKEN_NOT_FOUND"
+	CodeNoData              = "NO_DATA"
+	CodeNotFound            = "NOT_FOUND"
+	CodeForbidden           = "FORBIDDEN"
+	CodeInvalidAPIKey       = "INVALID_API_KEY"
+	CodeRateLimited         = "RATE_LIMITED"
+	CodeCommitmentMismatch  = "COMMITMENT_MISMATCH"
+	CodeRequestTooLarge     = "REQUEST_TOO_LARGE"
+	CodeTooManySubscribers  = "TOO_MANY_SUBSCRIBERS"
+	CodeUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	CodeInternal            = "INTERNAL_ERROR"
+)
+
+// RequestIDKey is the gin context key holding the request ID
+const RequestIDKey = "request_id"
+
+// Response is the JSON envelope of every error response
+type Response struct {
+	Error     string `json:"error" example:"token 'abc' is not supported"`
+	Code      string `json:"code" example:"TOKEN_NOT_FOUND"`
+	RequestID string `json:"request_id,omitempty" example:"4f9c2a7d1e3b8a60"`
+}
+
+// Error is an error answered with a given status and code
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Err     error // cause, logged but never sent
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func InvalidParameter(message string) *Error {
+	return New(http.StatusBadRequest, CodeInvalidParameter, message)
+}
+
+// Internal hides err from the client behind a generic message
+func Internal(err error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Message: "Internal server error", Err: err}
+}
+
+// From maps any error to the status, code and message it is answered with.
+// Domain errors get their own codes, anything else is an internal error.
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	message := "Internal server error"
+	var domainErr *quotes.Error
+	if errors.As(err, &domainErr) {
+		message = domainErr.Message
+	}
+
+	switch {
+	case errors.Is(err, quotes.ErrTokenNotFound):
+		return &Error{Status: http.StatusNotFound, Code: CodeTokenNotFound, Message: message, Err: err}
+	case errors.Is(err, quotes.ErrNoData):
+		return &Error{Status: http.StatusNotFound, Code: CodeNoData, Message: message, Err: err}
+	case errors.Is(err, quotes.ErrInvalidRange):
+		return &Error{Status: http.StatusBadRequest, Code: CodeInvalidRange, Message: message, Err: err}
+	case errors.Is(err, quotes.ErrCommitmentMismatch):
+		return &Error{Status: http.StatusConflict, Code: CodeCommitmentMismatch, Message: message, Err: err}
+	case errors.Is(err, quotes.ErrUpstreamUnavailable):
+		return &Error{Status: http.StatusServiceUnavailable, Code: CodeUpstreamUnavailable, Message: message, Err: err}
+	default:
+		return Internal(err)
+	}
+}
@@ -1,16 +1,49 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net"
+	"net/http"
 	"quotes/internal/config"
+	"quotes/internal/core/api/graphql"
+	"quotes/internal/core/api/http/middleware"
+	httpGetAttestation "quotes/internal/core/api/http/oracle/get_attestation"
+	httpGetCandles "quotes/internal/core/api/http/oracle/get_candles"
+	httpGetFeedInfo "quotes/internal/core/api/http/oracle/get_feed_info"
+	httpGetOracleKey "quotes/internal/core/api/http/oracle/get_key"
+	httpGetProof "quotes/internal/core/api/http/proofs/get_proof"
+	httpConvert "quotes/internal/core/api/http/quotes/convert"
+	httpEvents "quotes/internal/core/api/http/quotes/events"
 	httpGetAll "quotes/internal/core/api/http/quotes/get_all"
+	httpGetAverage "quotes/internal/core/api/http/quotes/get_average"
 	httpGetByToken "quotes/internal/core/api/http/quotes/get_by_token"
 	httpGetCount "quotes/internal/core/api/http/quotes/get_count"
+	httpGetDaily "quotes/internal/core/api/http/quotes/get_daily"
 	httpGetLatest "quotes/internal/core/api/http/quotes/get_latest"
+	httpGetPair "quotes/internal/core/api/http/quotes/get_pair"
+	httpGetStats "quotes/internal/core/api/http/quotes/get_stats"
+	httpGetTickers "quotes/internal/core/api/http/quotes/get_tickers"
+	httpStream "quotes/internal/core/api/http/quotes/stream"
+	httpValuate "quotes/internal/core/api/http/quotes/valuate"
+	appGetAttestation "quotes/internal/core/application/oracle/get_attestation"
+	appGetCandles "quotes/internal/core/application/oracle/get_candles"
+	appGetProof "quotes/internal/core/application/proofs/get_proof"
+	appConvert "quotes/internal/core/application/quotes/convert"
 	appGetAll "quotes/internal/core/application/quotes/get_all"
+	appGetAverage "quotes/internal/core/application/quotes/get_average"
 	appGetByToken "quotes/internal/core/application/quotes/get_by_token"
 	appGetCount "quotes/internal/core/application/quotes/get_count"
+	appGetDaily "quotes/internal/core/application/quotes/get_daily"
 	appGetLatest "quotes/internal/core/application/quotes/get_latest"
+	appGetPair "quotes/internal/core/application/quotes/get_pair"
+	appGetStats "quotes/internal/core/application/quotes/get_stats"
+	appGetTickers "quotes/internal/core/application/quotes/get_tickers"
+	appValuate "quotes/internal/core/application/quotes/valuate"
+	"quotes/internal/core/infrastructure/cache"
+	"quotes/internal/core/infrastructure/pubsub"
+	"quotes/internal/core/infrastructure/signing"
 	"quotes/internal/core/infrastructure/storage/repositories"
 
 	"github.com/gin-gonic/gin"
@@ -21,9 +54,15 @@ type App struct {
 	config *config.Config
 	db     *gorm.DB
 	router *gin.Engine
+	server *http.Server
+
+	// closeStreams cancels request contexts so WebSocket and SSE streams end instead of holding Shutdown
+	closeStreams context.CancelFunc
 }
 
-func NewApp(cfg *config.Config, db *gorm.DB) *App {
+// NewApp builds the HTTP API; oracle endpoints are served only when signer is not nil,
+// and the Harbinger feed only when harbingerAssets is not empty as well
+func NewApp(cfg *config.Config, db *gorm.DB, quoteCache *cache.QuoteCache, hub *pubsub.Hub, signer *signing.Signer, harbingerAssets []appGetCandles.Asset) (*App, error) {
 	// Set Gin mode
 	if cfg.Server.Host == "localhost" {
 		gin.SetMode(gin.DebugMode)
@@ -33,51 +72,111 @@ func NewApp(cfg *config.Config, db *gorm.DB) *App {
 
 	// Create Gin engine
 	router := gin.New()
+	// Renders errors of every later middleware and handler as the JSON error envelope
+	router.Use(middleware.Errors)
 	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
-
-	// Add CORS middleware
-	router.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+	router.Use(gin.CustomRecovery(middleware.Recover))
+	router.NoRoute(middleware.NoRoute)
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
+	// Only listed proxies may set the client IP used for rate limiting
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Printf("Invalid trusted proxies configuration: %v", err)
+	}
 
-		c.Next()
-	})
+	// CORS policy from config; preflights only advertise the methods registered for the path
+	router.Use(middleware.NewCORS(cfg, router.Routes).Handle)
 
 	// Create repositories
 	quoteRepo := repositories.NewQuoteRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+
+	// Throttle requests per client IP / API key (after CORS so preflights are not counted)
+	router.Use(middleware.NewRateLimiter(cfg, apiKeyRepo).Handle)
 
-	// Create application actions
-	getLatestAction := appGetLatest.New(quoteRepo)
+	// Create application actions (reads of quotes go through the cache)
+	getLatestAction := appGetLatest.New(quoteCache)
 	getCountAction := appGetCount.New(quoteRepo)
-	getAllAction := appGetAll.New(quoteRepo)
-	getByTokenAction := appGetByToken.New(quoteRepo)
+	getAllAction := appGetAll.New(quoteCache)
+	getByTokenAction := appGetByToken.New(quoteCache)
+	getAverageAction := appGetAverage.New(quoteCache, cfg.GetAverageMaxGap())
+	getStatsAction := appGetStats.New(quoteRepo) // aggregated in SQL, bypasses the cache
+	getTickersAction := appGetTickers.New(quoteCache)
+	getDailyAction := appGetDaily.New(quoteRepo) // aggregated in SQL, bypasses the cache
+	convertAction := appConvert.New(quoteCache, cfg.GetConversionMaxQuoteAge())
+	valuateAction := appValuate.New(quoteRepo, cfg.GetValuationMaxQuoteAge())
+	getPairAction := appGetPair.New(quoteRepo)
 
 	// Create HTTP handlers
-	getLatestHandler := httpGetLatest.New(getLatestAction)
+	getLatestHandler := httpGetLatest.New(getLatestAction, cfg)
 	getCountHandler := httpGetCount.New(getCountAction)
-	getAllHandler := httpGetAll.New(getAllAction)
-	getByTokenHandler := httpGetByToken.New(getByTokenAction)
+	getAllHandler := httpGetAll.New(getAllAction, cfg)
+	getByTokenHandler := httpGetByToken.New(getByTokenAction, cfg)
+	streamHandler := httpStream.New(hub, getByTokenAction, cfg)
+	eventsHandler := httpEvents.New(hub, getByTokenAction, cfg)
+	graphqlHandler, err := graphql.New(cfg, getLatestAction, getByTokenAction, getCountAction)
+	if err != nil {
+		return nil, err
+	}
+	averageHandler := httpGetAverage.New(getAverageAction, cfg)
+	statsHandler := httpGetStats.New(getStatsAction, cfg)
+	tickersHandler := httpGetTickers.New(getTickersAction, cfg)
+	dailyHandler := httpGetDaily.New(getDailyAction, cfg)
+	convertHandler := httpConvert.New(convertAction, cfg)
+	valuateHandler := httpValuate.New(valuateAction, cfg)
+	pairHandler := httpGetPair.New(getPairAction, cfg)
+
+	var attestationHandler *httpGetAttestation.Handler
+	var oracleKeyHandler *httpGetOracleKey.Handler
+	if signer != nil {
+		getAttestationAction := appGetAttestation.New(quoteCache, signer, cfg.Oracle.Decimals, cfg.GetAverageMaxGap())
+		attestationHandler = httpGetAttestation.New(getAttestationAction, cfg)
+		oracleKeyHandler = httpGetOracleKey.New(signer, cfg)
+	}
+	var candlesHandler *httpGetCandles.Handler
+	var feedInfoHandler *httpGetFeedInfo.Handler
+	if signer != nil && len(harbingerAssets) > 0 {
+		getCandlesAction := appGetCandles.New(quoteCache, signer, harbingerAssets, cfg.GetHarbingerCandle(), cfg.GetAverageMaxGap())
+		candlesHandler = httpGetCandles.New(getCandlesAction, cfg)
+		feedInfoHandler = httpGetFeedInfo.New(signer, harbingerAssets, cfg)
+	}
+
+	var proofHandler *httpGetProof.Handler
+	if cfg.Proofs.Enabled {
+		getProofAction := appGetProof.New(quoteRepo, repositories.NewPriceCommitmentRepository(db))
+		proofHandler = httpGetProof.New(getProofAction, cfg)
+	}
 
 	// Create router
-	httpRouter := NewRouter(getLatestHandler, getCountHandler, getAllHandler, getByTokenHandler)
+	httpRouter := NewRouter(getLatestHandler, getCountHandler, getAllHandler, getByTokenHandler, streamHandler, eventsHandler, graphqlHandler, averageHandler, statsHandler, tickersHandler, dailyHandler, convertHandler, valuateHandler, pairHandler, attestationHandler, oracleKeyHandler, candlesHandler, feedInfoHandler, proofHandler)
 	httpRouter.SetupRoutes(router)
 
+	closing, closeStreams := context.WithCancel(context.Background())
+
 	return &App{
 		config: cfg,
 		db:     db,
 		router: router,
+		server: &http.Server{
+			Addr:    cfg.Server.Host + ":" + cfg.Server.Port,
+			Handler: router,
+			BaseContext: func(net.Listener) context.Context {
+				return closing
+			},
+		},
+		closeStreams: closeStreams,
+	}, nil
+}
+
+func (a *App) Run(ctx context.Context) error {
+	log.Printf("Starting HTTP server on %s", a.server.Addr)
+	if err := a.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
 	}
+	return nil
 }
 
-func (a *App) Run() error {
-	addr := a.config.Server.Host + ":" + a.config.Server.Port
-	log.Printf("Starting HTTP server on %s", addr)
-	return a.router.Run(addr)
+// Stop stops accepting connections, ends WebSocket and SSE streams and waits for in-flight requests
+func (a *App) Stop(ctx context.Context) error {
+	a.closeStreams()
+	return a.server.Shutdown(ctx)
 }
@@ -0,0 +1,223 @@
+package format
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"quotes/internal/core/domain/quotes"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// Arrow IPC stream writer for quotes.
+//
+// Schemas are simple (timestamp + float64 columns, e.g. one per supported currency), so instead of
+// pulling in the whole Arrow library the Schema and RecordBatch flatbuffer messages are built
+// directly, following format/Schema.fbs and format/Message.fbs of the Arrow specification.
+
+// Flatbuffer enum values from the Arrow format definition
+const (
+	arrowMetadataV5        = 4
+	arrowHeaderSchema      = 1
+	arrowHeaderRecordBatch = 3
+	arrowTypeFloatingPoint = 3
+	arrowTypeTimestamp     = 10
+	arrowPrecisionDouble   = 2
+	arrowTimeUnitSecond    = 0
+)
+
+// arrowContinuation prefixes every encapsulated message in the stream format
+const arrowContinuation = 0xFFFFFFFF
+
+// WriteArrowStream writes quotes as an Arrow IPC stream: schema message, a single record batch
+// and the end-of-stream marker. Column "timestamp" is timestamp[s, tz=UTC], the remaining
+// columns are float64 prices named after the supported currencies.
+func WriteArrowStream(w io.Writer, quotesList []quotes.Quote) error {
+	currencies := quotes.GetSupportedCurrencies()
+
+	names := make([]string, len(currencies))
+	columns := make([][]float64, len(currencies))
+	for j, currency := range currencies {
+		names[j] = string(currency)
+		columns[j] = make([]float64, len(quotesList))
+	}
+	timestamps := make([]time.Time, len(quotesList))
+	for i, quote := range quotesList {
+		timestamps[i] = quote.Timestamp
+		for j, currency := range currencies {
+			columns[j][i] = quote.Price(currency)
+		}
+	}
+
+	return writeArrowTable(w, timestamps, names, columns)
+}
+
+// WritePairArrowStream writes pair quotes as an Arrow IPC stream with the columns timestamp,
+// price, base_price, quote_price and quote_timestamp (float64 unix seconds)
+func WritePairArrowStream(w io.Writer, pairQuotes []quotes.PairQuote) error {
+	names := []string{"price", "base_price", "quote_price", "quote_timestamp"}
+	columns := make([][]float64, len(names))
+	for j := range columns {
+		columns[j] = make([]float64, len(pairQuotes))
+	}
+	timestamps := make([]time.Time, len(pairQuotes))
+	for i, pairQuote := range pairQuotes {
+		timestamps[i] = pairQuote.Timestamp
+		columns[0][i] = pairQuote.Price
+		columns[1][i] = pairQuote.BasePrice
+		columns[2][i] = pairQuote.QuotePrice
+		columns[3][i] = float64(pairQuote.QuoteTimestamp.Unix())
+	}
+
+	return writeArrowTable(w, timestamps, names, columns)
+}
+
+// writeArrowTable writes a "timestamp" column followed by float64 columns as an Arrow IPC stream
+func writeArrowTable(w io.Writer, timestamps []time.Time, names []string, columns [][]float64) error {
+	if err := writeArrowMessage(w, arrowSchemaMessage(names), nil); err != nil {
+		return err
+	}
+
+	if len(timestamps) > 0 {
+		meta, body := arrowRecordBatchMessage(timestamps, columns)
+		if err := writeArrowMessage(w, meta, body); err != nil {
+			return err
+		}
+	}
+
+	eos := make([]byte, 8)
+	binary.LittleEndian.PutUint32(eos, arrowContinuation)
+	_, err := w.Write(eos)
+	return err
+}
+
+// writeArrowMessage writes the continuation marker, the padded metadata length, the metadata and the body
+func writeArrowMessage(w io.Writer, meta, body []byte) error {
+	padded := (len(meta) + 7) &^ 7
+
+	prefix := make([]byte, 8)
+	binary.LittleEndian.PutUint32(prefix[0:4], arrowContinuation)
+	binary.LittleEndian.PutUint32(prefix[4:8], uint32(padded))
+	if _, err := w.Write(prefix); err != nil {
+		return err
+	}
+	if _, err := w.Write(meta); err != nil {
+		return err
+	}
+	if _, err := w.Write(make([]byte, padded-len(meta))); err != nil {
+		return err
+	}
+	if len(body) > 0 {
+		if _, err := w.Write(body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func arrowSchemaMessage(names []string) []byte {
+	b := flatbuffers.NewBuilder(1024)
+
+	fields := make([]flatbuffers.UOffsetT, 0, len(names)+1)
+
+	tz := b.CreateString("UTC")
+	b.StartObject(2) // Timestamp
+	b.PrependInt16Slot(0, arrowTimeUnitSecond, 0)
+	b.PrependUOffsetTSlot(1, tz, 0)
+	timestampType := b.EndObject()
+	fields = append(fields, arrowField(b, "timestamp", arrowTypeTimestamp, timestampType))
+
+	for _, name := range names {
+		b.StartObject(1) // FloatingPoint
+		b.PrependInt16Slot(0, arrowPrecisionDouble, 0)
+		doubleType := b.EndObject()
+		fields = append(fields, arrowField(b, name, arrowTypeFloatingPoint, doubleType))
+	}
+
+	b.StartVector(4, len(fields), 4)
+	for i := len(fields) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(fields[i])
+	}
+	fieldsVector := b.EndVector(len(fields))
+
+	b.StartObject(4) // Schema
+	b.PrependUOffsetTSlot(1, fieldsVector, 0)
+	schema := b.EndObject()
+
+	return arrowFinishMessage(b, arrowHeaderSchema, schema, 0)
+}
+
+func arrowField(b *flatbuffers.Builder, name string, typeType byte, typeOffset flatbuffers.UOffsetT) flatbuffers.UOffsetT {
+	nameOffset := b.CreateString(name)
+
+	// Readers require the children vector to be present even for primitive types
+	b.StartVector(4, 0, 4)
+	children := b.EndVector(0)
+
+	b.StartObject(7) // Field
+	b.PrependUOffsetTSlot(0, nameOffset, 0)
+	b.PrependBoolSlot(1, false, false)
+	b.PrependByteSlot(2, typeType, 0)
+	b.PrependUOffsetTSlot(3, typeOffset, 0)
+	b.PrependUOffsetTSlot(5, children, 0)
+	return b.EndObject()
+}
+
+// arrowRecordBatchMessage builds the record batch metadata and body. Every column has an empty
+// validity buffer (no nulls) followed by its 8-byte values, so all buffers stay 8-byte aligned.
+func arrowRecordBatchMessage(timestamps []time.Time, values [][]float64) ([]byte, []byte) {
+	rows := len(timestamps)
+	columns := len(values) + 1
+	columnSize := rows * 8
+
+	body := make([]byte, columns*columnSize)
+	for i, timestamp := range timestamps {
+		binary.LittleEndian.PutUint64(body[i*8:], uint64(timestamp.Unix()))
+		for j, column := range values {
+			offset := (j+1)*columnSize + i*8
+			binary.LittleEndian.PutUint64(body[offset:], math.Float64bits(column[i]))
+		}
+	}
+
+	b := flatbuffers.NewBuilder(1024)
+
+	b.StartVector(16, columns, 8)
+	for i := 0; i < columns; i++ {
+		// FieldNode{length, null_count}
+		b.Prep(8, 16)
+		b.PrependInt64(0)
+		b.PrependInt64(int64(rows))
+	}
+	nodes := b.EndVector(columns)
+
+	b.StartVector(16, columns*2, 8)
+	for i := columns - 1; i >= 0; i-- {
+		// Buffer{offset, length}, prepended in reverse: values then validity
+		b.Prep(8, 16)
+		b.PrependInt64(int64(columnSize))
+		b.PrependInt64(int64(i * columnSize))
+		b.Prep(8, 16)
+		b.PrependInt64(0)
+		b.PrependInt64(int64(i * columnSize))
+	}
+	buffers := b.EndVector(columns * 2)
+
+	b.StartObject(5) // RecordBatch
+	b.PrependInt64Slot(0, int64(rows), 0)
+	b.PrependUOffsetTSlot(1, nodes, 0)
+	b.PrependUOffsetTSlot(2, buffers, 0)
+	batch := b.EndObject()
+
+	return arrowFinishMessage(b, arrowHeaderRecordBatch, batch, int64(len(body))), body
+}
+
+func arrowFinishMessage(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, bodyLength int64) []byte {
+	b.StartObject(5) // Message
+	b.PrependInt16Slot(0, arrowMetadataV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, bodyLength, 0)
+	b.Finish(b.EndObject())
+	return b.FinishedBytes()
+}
@@ -0,0 +1,75 @@
+package format
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// finalMaxAge is used for windows that can no longer change
+const finalMaxAge = 365 * 24 * time.Hour
+
+// Freshness controls the Cache-Control header of a response
+type Freshness struct {
+	MaxAge    time.Duration
+	Immutable bool
+}
+
+// ForWindow returns the freshness of a response covering quotes up to windowEnd.
+// Windows entirely older than the finality horizon are immutable and cached for a long time;
+// windows touching "now" (or with no explicit end) get the short live max-age.
+func ForWindow(windowEnd time.Time, finality, liveMaxAge time.Duration) Freshness {
+	if !windowEnd.IsZero() && windowEnd.Before(time.Now().Add(-finality)) {
+		return Freshness{MaxAge: finalMaxAge, Immutable: true}
+	}
+	return Freshness{MaxAge: liveMaxAge}
+}
+
+// CacheControl renders the Cache-Control header value
+func (f Freshness) CacheControl() string {
+	value := fmt.Sprintf("public, max-age=%d", int64(f.MaxAge/time.Second))
+	if f.Immutable {
+		value += ", immutable"
+	}
+	return value
+}
+
+// ETag returns a strong entity tag for an encoded representation
+func ETag(contentType string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(contentType))
+	h.Write([]byte{0})
+	h.Write(body)
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// NotModified evaluates If-None-Match and If-Modified-Since (RFC 9110, section 13).
+// If-Modified-Since is ignored when If-None-Match is present.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
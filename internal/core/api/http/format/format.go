@@ -0,0 +1,215 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	quotesv1 "quotes/api/proto/quotes/v1"
+	"quotes/internal/core/domain/quotes"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Content types that can be requested through the Accept header
+const (
+	MIMEJSON     = gin.MIMEJSON
+	MIMEMsgPack  = "application/msgpack"
+	MIMEProtobuf = "application/x-protobuf"
+	MIMEArrow    = "application/vnd.apache.arrow.stream"
+)
+
+// offered lists the supported formats; JSON goes first so that "*/*" keeps returning JSON
+var offered = []string{MIMEJSON, MIMEMsgPack, MIMEProtobuf, MIMEArrow}
+
+// Negotiate returns the response format matching the Accept header.
+// Falls back to JSON when the header is missing or nothing matches.
+func Negotiate(c *gin.Context) string {
+	if f := c.NegotiateFormat(offered...); f != "" {
+		return f
+	}
+	return MIMEJSON
+}
+
+// Quotes writes a list of quotes in the negotiated format
+func Quotes(c *gin.Context, code int, quotesList []quotes.Quote, freshness Freshness) {
+	var (
+		contentType string
+		body        []byte
+		err         error
+	)
+
+	switch Negotiate(c) {
+	case MIMEMsgPack:
+		contentType = MIMEMsgPack + "; charset=utf-8"
+		body, err = encodeMsgPack(quotesList)
+	case MIMEProtobuf:
+		contentType = MIMEProtobuf
+		body, err = proto.Marshal(ToProtoList(quotesList))
+	case MIMEArrow:
+		contentType = MIMEArrow
+		body, err = encodeArrow(quotesList)
+	default:
+		contentType = MIMEJSON + "; charset=utf-8"
+		body, err = json.Marshal(quotesList)
+	}
+
+	write(c, code, contentType, body, err, newestTimestamp(quotesList), freshness)
+}
+
+// Quote writes a single quote in the negotiated format.
+// Arrow has no notion of a single row, so the quote is sent as a one-row batch.
+func Quote(c *gin.Context, code int, quote quotes.Quote, freshness Freshness) {
+	var (
+		contentType string
+		body        []byte
+		err         error
+	)
+
+	switch Negotiate(c) {
+	case MIMEMsgPack:
+		contentType = MIMEMsgPack + "; charset=utf-8"
+		body, err = encodeMsgPack(quote)
+	case MIMEProtobuf:
+		contentType = MIMEProtobuf
+		body, err = proto.Marshal(ToProto(quote))
+	case MIMEArrow:
+		contentType = MIMEArrow
+		body, err = encodeArrow([]quotes.Quote{quote})
+	default:
+		contentType = MIMEJSON + "; charset=utf-8"
+		body, err = json.Marshal(quote)
+	}
+
+	write(c, code, contentType, body, err, quote.Timestamp, freshness)
+}
+
+// PairQuotes writes a series of pair quotes in the negotiated format
+func PairQuotes(c *gin.Context, code int, pairQuotes []quotes.PairQuote, freshness Freshness) {
+	var (
+		contentType string
+		body        []byte
+		err         error
+	)
+
+	switch Negotiate(c) {
+	case MIMEMsgPack:
+		contentType = MIMEMsgPack + "; charset=utf-8"
+		body, err = encodeMsgPack(pairQuotes)
+	case MIMEProtobuf:
+		contentType = MIMEProtobuf
+		body, err = proto.Marshal(toProtoPairList(pairQuotes))
+	case MIMEArrow:
+		contentType = MIMEArrow
+		var buf bytes.Buffer
+		err = WritePairArrowStream(&buf, pairQuotes)
+		body = buf.Bytes()
+	default:
+		contentType = MIMEJSON + "; charset=utf-8"
+		body, err = json.Marshal(pairQuotes)
+	}
+
+	var newest time.Time
+	if len(pairQuotes) > 0 {
+		newest = pairQuotes[len(pairQuotes)-1].Timestamp
+	}
+	write(c, code, contentType, body, err, newest, freshness)
+}
+
+// JSON writes a JSON document with caching headers; lastModified may be zero
+func JSON(c *gin.Context, code int, v any, lastModified time.Time, freshness Freshness) {
+	body, err := json.Marshal(v)
+	write(c, code, MIMEJSON+"; charset=utf-8", body, err, lastModified, freshness)
+}
+
+// write sends an encoded body with caching headers, answering conditional requests with 304
+func write(c *gin.Context, code int, contentType string, body []byte, err error, lastModified time.Time, freshness Freshness) {
+	if err != nil {
+		_ = c.Error(fmt.Errorf("failed to encode response: %w", err))
+		return
+	}
+
+	etag := ETag(contentType, body)
+
+	header := c.Writer.Header()
+	header.Set("ETag", etag)
+	header.Add("Vary", "Accept")
+	header.Set("Cache-Control", freshness.CacheControl())
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if code == http.StatusOK && NotModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(code, contentType, body)
+}
+
+func encodeMsgPack(v any) ([]byte, error) {
+	var body []byte
+	var handle codec.MsgpackHandle
+	err := codec.NewEncoderBytes(&body, &handle).Encode(v)
+	return body, err
+}
+
+func encodeArrow(quotesList []quotes.Quote) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteArrowStream(&buf, quotesList); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newestTimestamp(quotesList []quotes.Quote) time.Time {
+	var newest time.Time
+	for _, quote := range quotesList {
+		if quote.Timestamp.After(newest) {
+			newest = quote.Timestamp
+		}
+	}
+	return newest
+}
+
+// ToProto converts a quote to its protobuf message, shared with the gRPC API
+func ToProto(quote quotes.Quote) *quotesv1.Quote {
+	return &quotesv1.Quote{
+		Timestamp: timestamppb.New(quote.Timestamp),
+		Btc:       quote.BTC,
+		Usd:       quote.USD,
+		Eur:       quote.EUR,
+		Cny:       quote.CNY,
+		Jpy:       quote.JPY,
+		Krw:       quote.KRW,
+		Eth:       quote.ETH,
+		Gbp:       quote.GBP,
+	}
+}
+
+// ToProtoList converts quotes to a protobuf list
+func ToProtoList(quotesList []quotes.Quote) *quotesv1.QuoteList {
+	list := &quotesv1.QuoteList{Quotes: make([]*quotesv1.Quote, len(quotesList))}
+	for i, quote := range quotesList {
+		list.Quotes[i] = ToProto(quote)
+	}
+	return list
+}
+
+func toProtoPairList(pairQuotes []quotes.PairQuote) *quotesv1.PairQuoteList {
+	list := &quotesv1.PairQuoteList{Quotes: make([]*quotesv1.PairQuote, len(pairQuotes))}
+	for i, pairQuote := range pairQuotes {
+		list.Quotes[i] = &quotesv1.PairQuote{
+			Timestamp:      timestamppb.New(pairQuote.Timestamp),
+			Price:          pairQuote.Price,
+			BasePrice:      pairQuote.BasePrice,
+			QuotePrice:     pairQuote.QuotePrice,
+			QuoteTimestamp: timestamppb.New(pairQuote.QuoteTimestamp),
+		}
+	}
+	return list
+}
@@ -0,0 +1,25 @@
+package format
+
+import (
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"time"
+)
+
+// TimeFormat is the layout of timestamps in JSON responses: UTC with second precision
+const TimeFormat = "2006-01-02T15:04:05Z"
+
+// Time formats t in UTC with TimeFormat
+func Time(t time.Time) string {
+	return t.UTC().Format(TimeFormat)
+}
+
+// SupportedCurrencies lists the supported currencies for error messages (e.g. "btc, usd, eur")
+func SupportedCurrencies() string {
+	currencies := quotes.GetSupportedCurrencies()
+	names := make([]string, len(currencies))
+	for i, currency := range currencies {
+		names[i] = string(currency)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORS implements the configured cross-origin policy. Preflight responses only advertise
+// the methods actually registered for the requested path (intersected with the configured ones).
+type CORS struct {
+	allowAnyOrigin   bool
+	origins          *OriginMatcher
+	methods          map[string]bool // empty = every registered method
+	allowedHeaders   string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+
+	routes     func() gin.RoutesInfo
+	routesOnce sync.Once
+	registered []gin.RouteInfo
+}
+
+// NewCORS creates the middleware; routes is called once, on the first request, when every route is registered
+func NewCORS(cfg *config.Config, routes func() gin.RoutesInfo) *CORS {
+	c := &CORS{
+		origins:          NewOriginMatcher(cfg),
+		methods:          make(map[string]bool),
+		allowedHeaders:   strings.Join(cfg.CORS.AllowedHeaders, ", "),
+		exposedHeaders:   strings.Join(cfg.CORS.ExposedHeaders, ", "),
+		allowCredentials: cfg.CORS.AllowCredentials,
+		maxAge:           strconv.Itoa(cfg.CORS.MaxAgeSeconds),
+		routes:           routes,
+	}
+
+	c.allowAnyOrigin = c.origins.allowAny
+	for _, method := range cfg.CORS.AllowedMethods {
+		c.methods[strings.ToUpper(method)] = true
+	}
+
+	return c
+}
+
+func (m *CORS) Handle(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+	// The response depends on Origin unless every origin gets the same "*" answer
+	if m.allowCredentials || !m.allowAnyOrigin {
+		c.Writer.Header().Add("Vary", "Origin")
+	}
+
+	if origin == "" {
+		c.Next()
+		return
+	}
+
+	if !m.origins.Allowed(origin) {
+		if preflight {
+			_ = c.Error(apierror.New(http.StatusForbidden, apierror.CodeForbidden, "Origin not allowed"))
+			c.Abort()
+			return
+		}
+		c.Next()
+		return
+	}
+
+	header := c.Writer.Header()
+	if m.allowAnyOrigin && !m.allowCredentials {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if m.allowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if m.exposedHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", m.exposedHeaders)
+		}
+		c.Next()
+		return
+	}
+
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+
+	methods := m.allowedMethods(c.Request.URL.Path)
+	if len(methods) == 0 {
+		_ = c.Error(apierror.New(http.StatusNotFound, apierror.CodeNotFound, "Route not found"))
+		c.Abort()
+		return
+	}
+
+	requested := strings.ToUpper(c.GetHeader("Access-Control-Request-Method"))
+	allowed := false
+	for _, method := range methods {
+		if method == requested {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		_ = c.Error(apierror.New(http.StatusForbidden, apierror.CodeForbidden, "Method not allowed"))
+		c.Abort()
+		return
+	}
+
+	header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if m.allowedHeaders != "" {
+		header.Set("Access-Control-Allow-Headers", m.allowedHeaders)
+	}
+	header.Set("Access-Control-Max-Age", m.maxAge)
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+// OriginMatcher matches origins against cors.allowed_origins, for CORS and WebSocket upgrades alike
+type OriginMatcher struct {
+	allowAny bool
+	origins  []string
+}
+
+func NewOriginMatcher(cfg *config.Config) *OriginMatcher {
+	m := &OriginMatcher{}
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		if origin == "*" {
+			m.allowAny = true
+			continue
+		}
+		m.origins = append(m.origins, strings.ToLower(origin))
+	}
+	return m
+}
+
+// Allowed matches the origin against the configured list. An entry may contain
+// one "*" wildcard, e.g. "https://*.mavryk.org".
+func (m *OriginMatcher) Allowed(origin string) bool {
+	if m.allowAny {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	for _, allowed := range m.origins {
+		if prefix, suffix, wildcard := strings.Cut(allowed, "*"); wildcard {
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+			continue
+		}
+		if origin == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedMethods returns the registered (and configured) methods for a request path
+func (m *CORS) allowedMethods(path string) []string {
+	m.routesOnce.Do(func() {
+		m.registered = m.routes()
+	})
+
+	var methods []string
+	seen := make(map[string]bool)
+	for _, route := range m.registered {
+		if seen[route.Method] || !matchRoute(route.Path, path) {
+			continue
+		}
+		if len(m.methods) > 0 && !m.methods[route.Method] {
+			continue
+		}
+		seen[route.Method] = true
+		methods = append(methods, route.Method)
+	}
+	return methods
+}
+
+// matchRoute reports whether a request path matches a gin route pattern (":param" and "*wildcard" segments)
+func matchRoute(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if strings.HasPrefix(part, ":") {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
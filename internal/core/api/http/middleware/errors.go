@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"quotes/internal/core/api/http/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the request ID; a valid one sent by the client (or a proxy) is kept
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 64
+
+// Errors assigns every request an ID and renders the errors attached with c.Error as the
+// JSON error envelope. It must be the first middleware so that it sees every error.
+func Errors(c *gin.Context) {
+	requestID := c.GetHeader(RequestIDHeader)
+	if !isValidRequestID(requestID) {
+		requestID = newRequestID()
+	}
+	c.Set(apierror.RequestIDKey, requestID)
+	c.Header(RequestIDHeader, requestID)
+
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := apierror.From(c.Errors.Last().Err)
+	if err.Status >= http.StatusInternalServerError {
+		log.Printf("[%s] %s %s failed: %v", requestID, c.Request.Method, c.Request.URL.Path, err)
+	}
+	c.AbortWithStatusJSON(err.Status, apierror.Response{
+		Error:     err.Message,
+		Code:      err.Code,
+		RequestID: requestID,
+	})
+}
+
+// Recover turns panics into internal errors rendered by Errors
+func Recover(c *gin.Context, recovered any) {
+	_ = c.Error(apierror.Internal(fmt.Errorf("panic: %v", recovered)))
+	c.Abort()
+}
+
+// NoRoute answers unknown paths
+func NoRoute(c *gin.Context) {
+	_ = c.Error(apierror.New(http.StatusNotFound, apierror.CodeNotFound, "Route not found"))
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/domain/apikeys"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// keyCacheTTL bounds how long database key lookups (hits and misses) are remembered
+	keyCacheTTL = time.Minute
+	// sweepInterval is how often idle buckets are dropped
+	sweepInterval = time.Minute
+	// maxKeyMisses bounds the unknown keys remembered, since clients choose them freely
+	maxKeyMisses = 10000
+)
+
+type KeyRepository interface {
+	FindByKey(ctx context.Context, key string) (apikeys.APIKey, error)
+}
+
+type cachedKey struct {
+	key     apikeys.APIKey
+	found   bool
+	expires time.Time
+}
+
+// bucket is a token bucket refilled continuously at rate tokens per second up to burst
+type bucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64
+	burst  float64
+}
+
+// RateLimiter throttles requests with a token bucket per client IP (anonymous tier)
+// or per API key (tier of the key). Keys come from config or from the database.
+type RateLimiter struct {
+	enabled    bool
+	header     string
+	tiers      map[apikeys.Tier]config.RateLimitTier
+	configKeys map[string]apikeys.APIKey // by key hash
+	repo       KeyRepository
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	keyCache  map[string]cachedKey
+	keyMisses int // entries of keyCache with found == false
+	lastSweep time.Time
+}
+
+func NewRateLimiter(cfg *config.Config, repo KeyRepository) *RateLimiter {
+	tiers := make(map[apikeys.Tier]config.RateLimitTier, len(cfg.RateLimit.Tiers))
+	for name, tier := range cfg.RateLimit.Tiers {
+		tiers[apikeys.Tier(name)] = tier
+	}
+
+	configKeys := make(map[string]apikeys.APIKey, len(cfg.RateLimit.APIKeys))
+	for _, k := range cfg.RateLimit.APIKeys {
+		configKeys[strings.ToLower(k.KeyHash)] = apikeys.APIKey{Name: k.Name, Tier: apikeys.Tier(k.Tier)}
+	}
+
+	return &RateLimiter{
+		enabled:    cfg.RateLimit.Enabled,
+		header:     cfg.RateLimit.APIKeyHeader,
+		tiers:      tiers,
+		configKeys: configKeys,
+		repo:       repo,
+		buckets:    make(map[string]*bucket),
+		keyCache:   make(map[string]cachedKey),
+		lastSweep:  time.Now(),
+	}
+}
+
+func (l *RateLimiter) Handle(c *gin.Context) {
+	if !l.enabled || c.FullPath() == "/health" {
+		c.Next()
+		return
+	}
+
+	bucketKey := "ip:" + c.ClientIP()
+	tier := apikeys.TierAnonymous
+	charged := false
+
+	if raw := c.GetHeader(l.header); raw != "" {
+		key, known, err := l.knownKey(raw)
+		if !known {
+			// Resolving a new key may hit the database: charge it to the client IP first,
+			// so clients cycling through random keys are throttled like anonymous ones
+			if !l.charge(c, bucketKey, apikeys.TierAnonymous) {
+				return
+			}
+			charged = true
+			key, err = l.lookupKey(c.Request.Context(), raw)
+		}
+		switch {
+		case err == nil:
+			bucketKey = "key:" + key.Name
+			tier = key.Tier
+		case errors.Is(err, apikeys.ErrKeyNotFound):
+			_ = c.Error(apierror.New(http.StatusUnauthorized, apierror.CodeInvalidAPIKey, "Invalid API key"))
+			c.Abort()
+			return
+		default:
+			// Don't reject clients because the key store is unavailable, limit them as anonymous
+			log.Printf("Error looking up API key: %v", err)
+		}
+	}
+
+	// A request limited as anonymous was already charged before its key lookup
+	if (charged && bucketKey == "ip:"+c.ClientIP()) || l.charge(c, bucketKey, tier) {
+		c.Next()
+	}
+}
+
+// charge takes a token from the bucket for the tier and sets the rate limit headers.
+// When the bucket is empty it aborts the request with 429 and returns false.
+func (l *RateLimiter) charge(c *gin.Context, bucketKey string, tier apikeys.Tier) bool {
+	tierCfg, ok := l.tiers[tier]
+	if !ok {
+		log.Printf("Unknown rate limit tier %q, falling back to %s", tier, apikeys.TierAnonymous)
+		tierCfg = l.tiers[apikeys.TierAnonymous]
+	}
+	if tierCfg.Unlimited {
+		return true
+	}
+
+	allowed, remaining, retryAfter, reset := l.take(bucketKey, tierCfg, time.Now())
+
+	header := c.Writer.Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(tierCfg.Burst))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	if !allowed {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		_ = c.Error(apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, "Rate limit exceeded"))
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// take consumes a token from the bucket. It returns whether the request is allowed,
+// the remaining whole tokens, how long to wait for the next token and when the bucket is full again.
+func (l *RateLimiter) take(key string, tier config.RateLimitTier, now time.Time) (bool, int, time.Duration, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok || b.rate != tier.RPS || b.burst != float64(tier.Burst) {
+		b = &bucket{tokens: float64(tier.Burst), last: now, rate: tier.RPS, burst: float64(tier.Burst)}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	var retryAfter time.Duration
+	if !allowed && b.rate > 0 {
+		retryAfter = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+
+	reset := now
+	if b.rate > 0 {
+		reset = now.Add(time.Duration((b.burst - b.tokens) / b.rate * float64(time.Second)))
+	}
+
+	return allowed, int(b.tokens), retryAfter, reset
+}
+
+// sweep drops buckets that have refilled completely, they behave exactly like new ones. Caller holds l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst {
+			delete(l.buckets, key)
+		}
+	}
+	for hash, k := range l.keyCache {
+		if now.After(k.expires) {
+			l.forgetKey(hash)
+		}
+	}
+	l.lastSweep = now
+}
+
+// knownKey resolves a raw API key from config or from cached database lookups; known is false
+// when the database has to be asked
+func (l *RateLimiter) knownKey(raw string) (key apikeys.APIKey, known bool, err error) {
+	hash := apikeys.HashKey(raw)
+
+	if key, ok := l.configKeys[hash]; ok {
+		return key, true, nil
+	}
+	if l.repo == nil {
+		return apikeys.APIKey{}, true, apikeys.ErrKeyNotFound
+	}
+
+	l.mu.Lock()
+	cached, ok := l.keyCache[hash]
+	l.mu.Unlock()
+	if !ok || !time.Now().Before(cached.expires) {
+		return apikeys.APIKey{}, false, nil
+	}
+	if !cached.found {
+		return apikeys.APIKey{}, true, apikeys.ErrKeyNotFound
+	}
+	return cached.key, true, nil
+}
+
+// lookupKey resolves a raw API key from the database. Results are cached for keyCacheTTL,
+// misses only up to maxKeyMisses entries; errors are not cached.
+func (l *RateLimiter) lookupKey(ctx context.Context, raw string) (apikeys.APIKey, error) {
+	key, err := l.repo.FindByKey(ctx, raw)
+	if err != nil && !errors.Is(err, apikeys.ErrKeyNotFound) {
+		return apikeys.APIKey{}, err
+	}
+
+	hash := apikeys.HashKey(raw)
+	found := err == nil
+
+	l.mu.Lock()
+	if found || l.keyMisses < maxKeyMisses {
+		l.forgetKey(hash)
+		l.keyCache[hash] = cachedKey{key: key, found: found, expires: time.Now().Add(keyCacheTTL)}
+		if !found {
+			l.keyMisses++
+		}
+	}
+	l.mu.Unlock()
+
+	return key, err
+}
+
+// forgetKey drops a cached lookup. Caller holds l.mu.
+func (l *RateLimiter) forgetKey(hash string) {
+	if cached, ok := l.keyCache[hash]; ok {
+		if !cached.found {
+			l.keyMisses--
+		}
+		delete(l.keyCache, hash)
+	}
+}
@@ -0,0 +1,97 @@
+package get_attestation
+
+import (
+	"encoding/hex"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/application/oracle/get_attestation"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *get_attestation.Action
+	config *config.Config
+}
+
+func New(action *get_attestation.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response is a signed price attestation
+type Response struct {
+	Token         string  `json:"token" example:"mvrk"`
+	Currency      string  `json:"currency" example:"usd"`
+	Timestamp     string  `json:"timestamp" example:"2025-01-01T00:00:00Z"` // Time the price was observed
+	Price         float64 `json:"price" example:"0.12345678"`               // Signed price as a decimal, for display
+	PriceNat      string  `json:"price_nat" example:"12345678"`             // Signed price: price x 10^decimals
+	Decimals      int     `json:"decimals" example:"8"`
+	Michelson     string  `json:"michelson" example:"Pair 1735689600 \"mvrk\" \"usd\" 12345678"`
+	MichelsonType string  `json:"michelson_type" example:"pair timestamp string string nat"`
+	Packed        string  `json:"packed" example:"0x050707008096a4f70c070701000000046d76726b07070100000003757364008e85e30b"` // PACK of the payload
+	Signature     string  `json:"signature" example:"edsigtnQ5MbiQfWqHPnb9k1zh7W2vAu6sXqPRT1JiVZU2hoB4A89mizNhvFwEEMnBMabCKTjzQXuvw4rSgtAsmrZqgyVj5dL4WJ"`
+	PublicKey     string  `json:"public_key" example:"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"`
+	PublicKeyHash string  `json:"public_key_hash" example:"mv18Cw7psUrAAPBpXYd9CtCpHg9EgjHP9KTe"`
+}
+
+// GetAttestation godoc
+// @Summary      Signed price attestation for on-chain use
+// @Description  Latest observed price of a token, packed as Michelson bytes (pair timestamp string string nat) and signed by the oracle signer (remote signer or local key).
+// @Description  The signature verifies with CHECK_SIGNATURE on the packed bytes, or `octez-client check that bytes <packed> were signed by <public_key> to produce <signature>`.
+// @Description  Forward-filled prices are never attested: the payload carries the time the price was last observed.
+// @Tags         oracle
+// @Produce      json
+// @Param        token     path      string  true   "Token name (e.g., mvrk, usdt)"
+// @Param        currency  query     string  false  "Currency (default oracle.default_currency)"
+// @Success      200       {object}  Response
+// @Failure      400       {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER)"
+// @Failure      404       {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND) or no observed price (NO_DATA)"
+// @Failure      503       {object}  apierror.Response  "Database or signer unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/oracle/{token} [get]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	currency := quotes.Currency(h.config.Oracle.DefaultCurrency)
+	if value := c.Query("currency"); value != "" {
+		if !quotes.IsCurrencySupported(value) {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'currency' parameter. Supported: " + format.SupportedCurrencies()))
+			return
+		}
+		currency = quotes.Currency(strings.ToLower(value))
+	}
+
+	attestation, err := h.action.Execute(c.Request.Context(), tokenName, currency)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	format.JSON(c, http.StatusOK, toResponse(attestation), attestation.Payload.Timestamp, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
+}
+
+func toResponse(attestation oracle.Attestation) Response {
+	payload := attestation.Payload
+	return Response{
+		Token:         payload.Token,
+		Currency:      string(payload.Currency),
+		Timestamp:     format.Time(payload.Timestamp),
+		Price:         payload.DecimalPrice(),
+		PriceNat:      payload.Price.String(),
+		Decimals:      payload.Decimals,
+		Michelson:     payload.Michelson(),
+		MichelsonType: oracle.MichelsonType,
+		Packed:        "0x" + hex.EncodeToString(attestation.Packed),
+		Signature:     attestation.Signature,
+		PublicKey:     attestation.Key.PublicKey,
+		PublicKeyHash: attestation.Key.PublicKeyHash,
+	}
+}
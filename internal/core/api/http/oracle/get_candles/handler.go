@@ -0,0 +1,62 @@
+package get_candles
+
+import (
+	"encoding/hex"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/application/oracle/get_candles"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeFormat is the JavaScript ISO format used by Harbinger signers
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+type Handler struct {
+	action *get_candles.Action
+	config *config.Config
+}
+
+func New(action *get_candles.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response has the shape of a Harbinger signer /oracle response: messages[i] is signed by signatures[i]
+type Response struct {
+	Timestamp  string   `json:"timestamp" example:"2025-01-01T00:01:00.000Z"` // End of the newest candle
+	Messages   []string `json:"messages" example:"05070701000000084d56524b2d555344..."`
+	Signatures []string `json:"signatures" example:"edsigtnQ5MbiQfWqHPnb9k1zh7W2vAu6sXqPRT1JiVZU2hoB4A89mizNhvFwEEMnBMabCKTjzQXuvw4rSgtAsmrZqgyVj5dL4WJ"`
+}
+
+// GetCandles godoc
+// @Summary      Harbinger-compatible signed candles
+// @Description  Latest completed candle of every configured asset (e.g. MVRK-USD), in the message and signature format of a Harbinger signer `/oracle` endpoint, for existing Harbinger oracle contracts, normalizers and posters.
+// @Description  Each message is the hex PACK of `pair string (pair timestamp (pair timestamp (pair nat (pair nat (pair nat (pair nat nat))))))`: asset, start, end, open, high, low, close and volume, prices and volume with 6 decimals.
+// @Tags         oracle
+// @Produce      json
+// @Success      200  {object}  Response
+// @Failure      404  {object}  apierror.Response  "No observed price for any asset (NO_DATA)"
+// @Failure      503  {object}  apierror.Response  "Database or signer unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/harbinger/oracle [get]
+func (h *Handler) Handle(c *gin.Context) {
+	candles, err := h.action.Execute(c.Request.Context(), time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := Response{Messages: make([]string, len(candles)), Signatures: make([]string, len(candles))}
+	var newest time.Time
+	for i, candle := range candles {
+		response.Messages[i] = hex.EncodeToString(candle.Packed)
+		response.Signatures[i] = candle.Signature
+		if candle.Candle.End.After(newest) {
+			newest = candle.Candle.End
+		}
+	}
+	response.Timestamp = newest.UTC().Format(timeFormat)
+
+	format.JSON(c, http.StatusOK, response, newest, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
+}
@@ -0,0 +1,57 @@
+package get_feed_info
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/application/oracle/get_candles"
+	"quotes/internal/core/domain/oracle"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeySource interface {
+	Key() oracle.Key
+}
+
+type Handler struct {
+	keys   KeySource
+	assets []get_candles.Asset
+	config *config.Config
+}
+
+func New(keys KeySource, assets []get_candles.Asset, cfg *config.Config) *Handler {
+	return &Handler{keys: keys, assets: assets, config: cfg}
+}
+
+// Response has the shape of a Harbinger signer /info response
+type Response struct {
+	DataFeed   string   `json:"dataFeed" example:"Mavryk External Data"`
+	AssetNames []string `json:"assetNames" example:"MVRK-USD"`
+	PublicKey  string   `json:"publicKey" example:"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"` // Key to originate the oracle contract with
+	// How candle volumes are derived; not part of the Harbinger response
+	VolumeMethod string `json:"volumeMethod" example:"synthetic: rolling 24h USD volume of the candle quotes prorated to the candle length, in token units at the candle USD price, at least 1 (0.000001 token)"`
+}
+
+// GetFeedInfo godoc
+// @Summary      Harbinger-compatible feed description
+// @Description  Feed name, asset names and public key of the Harbinger-compatible candle feed, as a Harbinger signer `/info` endpoint returns them.
+// @Description  `volumeMethod` tells how candle volumes are derived: quotes carry the rolling 24h USD volume only, so a candle volume is that volume prorated to the candle length, in token units, and never below 1.
+// @Tags         oracle
+// @Produce      json
+// @Success      200  {object}  Response
+// @Router       /v1/harbinger/info [get]
+func (h *Handler) Handle(c *gin.Context) {
+	response := Response{
+		DataFeed:     h.config.Oracle.Harbinger.DataFeed,
+		AssetNames:   make([]string, len(h.assets)),
+		PublicKey:    h.keys.Key().PublicKey,
+		VolumeMethod: oracle.CandleVolumeMethod,
+	}
+	for i, asset := range h.assets {
+		response.AssetNames[i] = oracle.CandleAsset(asset.Token, asset.Currency)
+	}
+
+	format.JSON(c, http.StatusOK, response, time.Time{}, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
+}
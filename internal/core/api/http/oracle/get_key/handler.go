@@ -0,0 +1,35 @@
+package get_key
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/domain/oracle"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeySource interface {
+	Key() oracle.Key
+}
+
+type Handler struct {
+	keys   KeySource
+	config *config.Config
+}
+
+func New(keys KeySource, cfg *config.Config) *Handler {
+	return &Handler{keys: keys, config: cfg}
+}
+
+// GetKey godoc
+// @Summary      Oracle public key
+// @Description  Public key that signs oracle attestations, with its algorithm and key hash, for contracts and clients to pin.
+// @Tags         oracle
+// @Produce      json
+// @Success      200  {object}  oracle.Key
+// @Router       /v1/oracle/key [get]
+func (h *Handler) Handle(c *gin.Context) {
+	format.JSON(c, http.StatusOK, h.keys.Key(), time.Time{}, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
+}
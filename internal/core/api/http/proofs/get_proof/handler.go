@@ -0,0 +1,114 @@
+package get_proof
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/proofs/get_proof"
+	"quotes/internal/core/domain/proofs"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *get_proof.Action
+	config *config.Config
+}
+
+func New(action *get_proof.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Step is a sibling hash on the path from the leaf to the root
+type Step struct {
+	Hash     string `json:"hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Position string `json:"position" example:"left"` // Side the sibling is hashed on: left or right
+}
+
+// Response is an inclusion proof of a quote in the committed tree of its day
+type Response struct {
+	Token         string       `json:"token" example:"mvrk"`
+	Day           string       `json:"day" example:"2025-01-01"` // UTC day of the commitment
+	Root          string       `json:"root" example:"5feceb66ffc86f38d952786c6d696c79c2dbc239dd4e91b46729d73a27fb57e9"`
+	LeafCount     int          `json:"leaf_count" example:"1440"`
+	CommittedAt   string       `json:"committed_at" example:"2025-01-02T00:10:00Z"`
+	HashAlgorithm string       `json:"hash_algorithm" example:"sha256"`
+	Quote         quotes.Quote `json:"quote"`
+	Leaf          string       `json:"leaf" example:"mvrk,1735689600,0.00000123,0.12345678,0.11800000,0.90000000,19.00000000,170.00000000,0.00003500,0.09800000"`
+	LeafFormat    string       `json:"leaf_format" example:"token,unix_seconds,btc,usd,eur,cny,jpy,krw,eth,gbp (prices with 8 decimals)"`
+	LeafHash      string       `json:"leaf_hash" example:"6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"` // SHA-256(0x00 || leaf)
+	Index         int          `json:"index" example:"0"`                                                                    // Position of the leaf, in timestamp order
+	Proof         []Step       `json:"proof"`
+}
+
+// GetProof godoc
+// @Summary      Inclusion proof of a stored quote
+// @Description  Proves that the quote of a token at `ts` (or the last one before it on the same UTC day) is part of the daily Merkle commitment of that day.
+// @Description  Verify by hashing `leaf` as SHA-256(0x00 || leaf), then for each step SHA-256(0x01 || sibling || hash) when `position` is left, or SHA-256(0x01 || hash || sibling) when right; the result must equal `root`.
+// @Description  A day is committed once it is over and its quotes are final, when proofs.enabled is set.
+// @Tags         proofs
+// @Produce      json
+// @Param        token  path      string  true  "Token name (e.g., mvrk, usdt)"
+// @Param        ts     query     string  true  "Time of the quote: RFC3339, date, unix seconds or milliseconds, or relative time"
+// @Success      200    {object}  Response
+// @Failure      400    {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER)"
+// @Failure      404    {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND), day not committed or no quote (NO_DATA)"
+// @Failure      409    {object}  apierror.Response  "Stored quotes no longer match the committed root (COMMITMENT_MISMATCH)"
+// @Failure      503    {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/proofs/{token} [get]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	ts, ok, err := timeparam.Time(c, "ts", time.Now().UTC(), time.UTC)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !ok {
+		_ = c.Error(apierror.InvalidParameter("Missing 'ts' parameter. Use " + timeparam.Formats))
+		return
+	}
+
+	proof, err := h.action.Execute(c.Request.Context(), tokenName, ts)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	format.JSON(c, http.StatusOK, toResponse(proof), proof.Commitment.CommittedAt, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
+}
+
+func toResponse(proof get_proof.Proof) Response {
+	steps := make([]Step, len(proof.Steps))
+	for i, step := range proof.Steps {
+		position := "right"
+		if step.Left {
+			position = "left"
+		}
+		steps[i] = Step{Hash: step.Hash.String(), Position: position}
+	}
+
+	return Response{
+		Token:         proof.Commitment.Token,
+		Day:           proof.Commitment.Day.Format(time.DateOnly),
+		Root:          proof.Commitment.Root.String(),
+		LeafCount:     proof.Commitment.LeafCount,
+		CommittedAt:   format.Time(proof.Commitment.CommittedAt),
+		HashAlgorithm: "sha256",
+		Quote:         proof.Quote,
+		Leaf:          string(proof.Leaf),
+		LeafFormat:    proofs.LeafFormat,
+		LeafHash:      proofs.LeafHash(proof.Leaf).String(),
+		Index:         proof.Index,
+		Proof:         steps,
+	}
+}
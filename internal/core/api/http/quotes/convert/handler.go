@@ -0,0 +1,140 @@
+package convert
+
+import (
+	"math"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/convert"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *convert.Action
+	config *config.Config
+}
+
+func New(action *convert.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response is a converted amount along with the quotes used
+type Response struct {
+	Amount float64  `json:"amount" example:"12.5"`
+	From   string   `json:"from" example:"mvrk"`
+	To     string   `json:"to" example:"eur"`
+	Result float64  `json:"result" example:"0.89"`
+	Rate   float64  `json:"rate" example:"0.0712"`                       // Units of "to" per unit of "from"
+	At     string   `json:"at,omitempty" example:"2025-01-01T12:00:00Z"` // Requested moment, omitted for latest quotes
+	Path   []string `json:"path" example:"mvrk,eur"`                     // Symbols the conversion goes through
+	Quotes []Leg    `json:"quotes"`                                      // Quote prices used, in path order
+}
+
+// Leg is a quote price used by a conversion
+type Leg struct {
+	Token     string  `json:"token" example:"mvrk"`
+	Currency  string  `json:"currency" example:"eur"`
+	Price     float64 `json:"price" example:"0.0712"`
+	Timestamp string  `json:"timestamp" example:"2025-01-01T11:59:00Z"`
+	Inverse   bool    `json:"inverse" example:"false"` // The price was inverted (currency to token)
+}
+
+// Convert godoc
+// @Summary      Convert an amount between tokens and currencies
+// @Description  Converts between a token and a currency (mvrk -> eur, eur -> mvrk) or between two tokens through a currency priced for both, USD first (mvrk -> usd -> usdt).
+// @Description  Uses the latest quotes, or with "at" the last quotes at most conversion.max_quote_age_seconds before that moment.
+// @Tags         tokens
+// @Produce      json
+// @Param        amount  query     number  false  "Amount to convert (default 1)"
+// @Param        from    query     string  true   "Token or currency to convert from (e.g., mvrk)"
+// @Param        to      query     string  true   "Token or currency to convert to (e.g., eur, usdt)"
+// @Param        at      query     string  false  "Moment of the quotes: RFC3339, date (2025-01-01, in tz), unix seconds/milliseconds or relative (now-1h). Default: latest quotes"
+// @Param        tz      query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
+// @Success      200     {object}  Response
+// @Failure      400     {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404     {object}  apierror.Response  "No quote for a token at that moment (NO_DATA)"
+// @Failure      503     {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/convert [get]
+func (h *Handler) Handle(c *gin.Context) {
+	amount := 1.0
+	if value := c.Query("amount"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || math.IsInf(parsed, 0) || math.IsNaN(parsed) {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'amount' parameter. Must be a non-negative number"))
+			return
+		}
+		amount = parsed
+	}
+
+	from, to := strings.ToLower(c.Query("from")), strings.ToLower(c.Query("to"))
+	for _, param := range []struct{ name, symbol string }{{"from", from}, {"to", to}} {
+		if !quotes.IsSymbolSupported(param.symbol) {
+			_ = c.Error(apierror.InvalidParameter("Invalid '" + param.name + "' parameter. Use a supported token or currency (e.g., mvrk, usdt, usd, eur)"))
+			return
+		}
+	}
+	if from != to && !quotes.IsTokenSupported(from) && !quotes.IsTokenSupported(to) {
+		_ = c.Error(apierror.InvalidParameter("Cannot convert between two currencies, 'from' or 'to' must be a token"))
+		return
+	}
+
+	loc, err := timeparam.Location(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	now := time.Now()
+	at, hasAt, err := timeparam.Time(c, "at", now, loc)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if at.After(now) {
+		_ = c.Error(quotes.InvalidRange("Invalid 'at' parameter: must not be in the future"))
+		return
+	}
+
+	conversion, err := h.action.Execute(c.Request.Context(), amount, from, to, at)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := Response{
+		Amount: conversion.Amount,
+		From:   conversion.From,
+		To:     conversion.To,
+		Result: conversion.Result,
+		Rate:   conversion.Rate,
+		Path:   conversion.Path,
+		Quotes: make([]Leg, len(conversion.Legs)),
+	}
+	var newest time.Time
+	for i, leg := range conversion.Legs {
+		response.Quotes[i] = Leg{
+			Token:     leg.Token,
+			Currency:  string(leg.Currency),
+			Price:     leg.Price,
+			Timestamp: format.Time(leg.Timestamp),
+			Inverse:   leg.Inverse,
+		}
+		if leg.Timestamp.After(newest) {
+			newest = leg.Timestamp
+		}
+	}
+
+	freshness := format.Freshness{MaxAge: h.config.GetCacheMaxAge()}
+	if hasAt {
+		response.At = format.Time(at)
+		// Conversions at a finalized moment never change
+		freshness = format.ForWindow(at, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	}
+	format.JSON(c, http.StatusOK, response, newest, freshness)
+}
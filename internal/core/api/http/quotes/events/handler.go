@@ -0,0 +1,176 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/application/quotes/get_by_token"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/pubsub"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// retryMillis is the reconnection delay suggested to EventSource clients
+const retryMillis = 5000
+
+type Handler struct {
+	hub     *pubsub.Hub
+	action  *get_by_token.Action
+	config  *config.Config
+	clients atomic.Int64
+}
+
+func New(hub *pubsub.Hub, action *get_by_token.Action, cfg *config.Config) *Handler {
+	return &Handler{hub: hub, action: action, config: cfg}
+}
+
+// StreamTokenEvents godoc
+// @Summary      Live quotes for a token (Server-Sent Events)
+// @Description  Streams every new quote of the token as a "quote" event whose id is the quote's unix timestamp.
+// @Description  Reconnecting clients send Last-Event-ID to replay the quotes saved after it. Clients that fall behind are disconnected and should reconnect.
+// @Tags         stream
+// @Produce      text/event-stream
+// @Param        token          path    string  true   "Token name (e.g., mvrk, usdt)"
+// @Param        Last-Event-ID  header  string  false  "Id of the last received event (unix timestamp)"
+// @Success      200  {string}  string  "Event stream"
+// @Failure      400  {object}  apierror.Response  "Invalid Last-Event-ID (INVALID_PARAMETER)"
+// @Failure      404  {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND)"
+// @Failure      503  {object}  apierror.Response  "Too many subscribers (TOO_MANY_SUBSCRIBERS)"
+// @Router       /{token}/events [get]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	var since time.Time
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		seconds, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'Last-Event-ID' header. Must be a unix timestamp"))
+			return
+		}
+		since = time.Unix(seconds, 0)
+	}
+
+	if h.clients.Add(1) > int64(h.config.Stream.MaxSSEClients) {
+		h.clients.Add(-1)
+		c.Header("Retry-After", strconv.Itoa(retryMillis/1000))
+		_ = c.Error(apierror.New(http.StatusServiceUnavailable, apierror.CodeTooManySubscribers, "Too many subscribers"))
+		return
+	}
+	defer h.clients.Add(-1)
+
+	// Subscribe before replaying so quotes saved during the replay are not missed
+	sub := h.hub.Subscribe([]string{tokenName}, h.config.Stream.BufferSize)
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+	c.Status(http.StatusOK)
+
+	s := &stream{c: c, lastSent: since}
+	if err := s.write(fmt.Sprintf("retry: %d\n\n", retryMillis)); err != nil {
+		return
+	}
+
+	if !since.IsZero() {
+		if err := h.replay(s, tokenName, since); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.config.GetStreamHeartbeat())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Dropped as too slow; the client reconnects with Last-Event-ID
+				return
+			}
+			if err := s.sendQuote(event.Quote); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := s.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// replay sends the quotes saved after since, up to stream.max_replay of them
+func (h *Handler) replay(s *stream, tokenName string, since time.Time) error {
+	maxReplay := h.config.Stream.MaxReplay
+
+	quotesList, err := h.action.Execute(s.c.Request.Context(), tokenName, since, time.Now(), maxReplay+1)
+	if err != nil {
+		log.Printf("Error replaying quotes for %s: %v", tokenName, err)
+		return s.write("event: error\ndata: failed to replay quotes\n\n")
+	}
+
+	truncated := len(quotesList) > maxReplay
+	if truncated {
+		quotesList = quotesList[:maxReplay]
+	}
+
+	for _, quote := range quotesList {
+		if err := s.sendQuote(quote); err != nil {
+			return err
+		}
+	}
+
+	if truncated {
+		// The client has to fetch the gap through the REST API
+		return s.write(fmt.Sprintf("event: replay_truncated\ndata: %d\n\n", s.lastSent.Unix()))
+	}
+	return nil
+}
+
+// stream writes SSE frames for one client
+type stream struct {
+	c        *gin.Context
+	lastSent time.Time
+}
+
+// sendQuote sends a quote unless it is not newer than the last one sent
+func (s *stream) sendQuote(quote quotes.Quote) error {
+	if !quote.Timestamp.After(s.lastSent) {
+		return nil
+	}
+
+	data, err := json.Marshal(quote)
+	if err != nil {
+		return err
+	}
+
+	if err := s.write(fmt.Sprintf("id: %d\nevent: quote\ndata: %s\n\n", quote.Timestamp.Unix(), data)); err != nil {
+		return err
+	}
+	s.lastSent = quote.Timestamp
+	return nil
+}
+
+func (s *stream) write(frame string) error {
+	if _, err := s.c.Writer.WriteString(frame); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
@@ -2,6 +2,10 @@ package get_all
 
 import (
 	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
 	"quotes/internal/core/application/quotes/get_all"
 	domainQuotes "quotes/internal/core/domain/quotes"
 	"strconv"
@@ -12,10 +16,11 @@ import (
 
 type Handler struct {
 	action *get_all.Action
+	config *config.Config
 }
 
-func New(action *get_all.Action) *Handler {
-	return &Handler{action: action}
+func New(action *get_all.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
 }
 
 // GetQuotes godoc
@@ -23,43 +28,27 @@ func New(action *get_all.Action) *Handler {
 // @Description  Retrieve quotes for MVRK token with optional filters. Returns quotes within the specified time range.
 // @Tags         quotes
 // @Accept       json
-// @Produce      json
-// @Param        from    query     string  false  "Start time (RFC3339 format, e.g., 2025-01-01T00:00:00Z). Default: 24 hours ago"
-// @Param        to      query     string  false  "End time (RFC3339 format, e.g., 2025-01-01T23:59:59Z). Default: now"
+// @Produce      json,application/msgpack,application/x-protobuf,application/vnd.apache.arrow.stream
+// @Param        from    query     string  false  "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'"
+// @Param        to      query     string  false  "End time, same formats as 'from'. Default: now"
+// @Param        tz      query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
 // @Param        limit   query     int     false  "Maximum number of quotes to return. Default: no limit"
 // @Success      200     {array}   quotes.Quote  "List of quotes"
-// @Failure      400     {object}  map[string]string  "Invalid request parameters"
-// @Failure      500     {object}  map[string]string  "Internal server error"
+// @Header       200     {string}  ETag           "Strong entity tag of the representation"
+// @Header       200     {string}  Last-Modified  "Timestamp of the newest quote in the response"
+// @Header       200     {string}  Cache-Control  "Long and immutable for finalized windows, short otherwise"
+// @Success      304     "Not modified (If-None-Match / If-Modified-Since matched)"
+// @Failure      400     {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      503     {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
 // @Router       /quotes [get]
 func (h *Handler) Handle(c *gin.Context) {
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
 	limitStr := c.Query("limit")
 
-	now := time.Now()
-	from := now.Add(-24 * time.Hour)
-	to := now
-
-	if fromStr != "" {
-		if parsedFrom, err := time.Parse(time.RFC3339, fromStr); err == nil {
-			from = parsedFrom
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid 'from' parameter format. Use RFC3339 format (e.g., 2023-01-01T00:00:00Z)",
-			})
-			return
-		}
-	}
-
-	if toStr != "" {
-		if parsedTo, err := time.Parse(time.RFC3339, toStr); err == nil {
-			to = parsedTo
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid 'to' parameter format. Use RFC3339 format (e.g., 2023-01-01T00:00:00Z)",
-			})
-			return
-		}
+	// No parameters means the last 24 hours
+	timeRange, err := timeparam.ParseRange(c, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
 	limit := 0
@@ -67,29 +56,18 @@ func (h *Handler) Handle(c *gin.Context) {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
 			limit = parsedLimit
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid 'limit' parameter. Must be a positive integer",
-			})
+			_ = c.Error(apierror.InvalidParameter("Invalid 'limit' parameter. Must be a positive integer"))
 			return
 		}
 	}
 
-	if from.After(to) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid time range: 'from' must be before 'to'",
-		})
-		return
-	}
-
 	// Use mvrk token for /quotes endpoint
-	quotesList, err := h.action.Execute(c.Request.Context(), from, to, limit, string(domainQuotes.TokenMVRK))
+	quotesList, err := h.action.Execute(c.Request.Context(), timeRange.From, timeRange.To, limit, string(domainQuotes.TokenMVRK))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to get quotes",
-			"details": err.Error(),
-		})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, quotesList)
+	freshness := format.ForWindow(timeRange.To, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	format.Quotes(c, http.StatusOK, quotesList, freshness)
 }
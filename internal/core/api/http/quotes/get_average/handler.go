@@ -0,0 +1,172 @@
+package get_average
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/get_average"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *get_average.Action
+	config *config.Config
+}
+
+func New(action *get_average.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response is a price averaged over a window
+type Response struct {
+	Token            string  `json:"token" example:"mvrk"`
+	Method           string  `json:"method" example:"twap"`
+	Currency         string  `json:"currency" example:"usd"`
+	Value            float64 `json:"value" example:"0.0123"`
+	Points           int     `json:"points" example:"30"`                           // Quotes that contributed to the value
+	FilledPoints     int     `json:"filled_points" example:"0"`                     // Forward-filled quotes in the window, ignored
+	From             string  `json:"from" example:"2025-01-01T11:30:00Z"`           // Start of the requested window
+	To               string  `json:"to" example:"2025-01-01T12:00:00Z"`             // End of the requested window ("at")
+	WindowSeconds    int64   `json:"window_seconds" example:"1800"`                 // Length of the requested window
+	EffectiveFrom    string  `json:"effective_from" example:"2025-01-01T11:30:00Z"` // Start of the part covered by contributing quotes
+	EffectiveTo      string  `json:"effective_to" example:"2025-01-01T12:00:00Z"`   // End of the part covered by contributing quotes
+	EffectiveSeconds int64   `json:"effective_seconds" example:"1800"`              // Time during which a contributing price was fresh
+	GapSeconds       int64   `json:"gap_seconds" example:"0"`                       // Rest of the window, without a fresh price
+}
+
+// GetTWAP godoc
+// @Summary      Time-weighted average price of a token
+// @Description  Averages the price over the window ending at "at", weighting each observed price by how long it was the latest one.
+// @Description  A price stays fresh until the next quote or for averages.max_gap_seconds; longer gaps don't count (see gap_seconds).
+// @Description  Forward-filled prices are not observations and are ignored.
+// @Tags         tokens
+// @Produce      json
+// @Param        token     path      string  true   "Token name (e.g., mvrk, usdt)"
+// @Param        currency  query     string  false  "Currency (default usd)"
+// @Param        window    query     string  false  "Window length as a duration (30m, 1h) or seconds. Default: averages.default_window_seconds"
+// @Param        at        query     string  false  "End of the window: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-1h, now-1d). Default: now"
+// @Param        tz        query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
+// @Success      200       {object}  Response
+// @Failure      400       {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404       {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND) or no quotes in the window (NO_DATA)"
+// @Failure      503       {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /{token}/twap [get]
+func (h *Handler) HandleTWAP(c *gin.Context) {
+	h.handle(c, quotes.MethodTWAP)
+}
+
+// GetVWAP24h godoc
+// @Summary      Average price of a token weighted by the rolling 24h volume
+// @Description  Averages the price over the window ending at "at", weighting each observed price by the rolling 24h USD volume reported with it.
+// @Description  This is not a VWAP of the trades in the window: the upstream only reports 24h volumes. Quotes without a volume and forward-filled prices are ignored.
+// @Tags         tokens
+// @Produce      json
+// @Param        token     path      string  true   "Token name (e.g., mvrk, usdt)"
+// @Param        currency  query     string  false  "Currency (default usd)"
+// @Param        window    query     string  false  "Window length as a duration (30m, 1h) or seconds. Default: averages.default_window_seconds"
+// @Param        at        query     string  false  "End of the window: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-1h, now-1d). Default: now"
+// @Param        tz        query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
+// @Success      200       {object}  Response
+// @Failure      400       {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404       {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND) or no quotes in the window (NO_DATA)"
+// @Failure      503       {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /{token}/vwap24h [get]
+func (h *Handler) HandleVWAP24h(c *gin.Context) {
+	h.handle(c, quotes.MethodVWAP24h)
+}
+
+func (h *Handler) handle(c *gin.Context, method quotes.AverageMethod) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	currency := quotes.CurrencyUSD
+	if value := c.Query("currency"); value != "" {
+		if !quotes.IsCurrencySupported(value) {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'currency' parameter. Supported: " + format.SupportedCurrencies()))
+			return
+		}
+		currency = quotes.Currency(strings.ToLower(value))
+	}
+
+	window := time.Duration(h.config.Averages.DefaultWindowSeconds) * time.Second
+	if value := c.Query("window"); value != "" {
+		parsed, ok := parseWindow(value)
+		if !ok {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'window' parameter. Use a duration (e.g., 30m, 1h) or a number of seconds"))
+			return
+		}
+		window = parsed
+	}
+	if maxWindow := time.Duration(h.config.Averages.MaxWindowSeconds) * time.Second; window > maxWindow {
+		_ = c.Error(quotes.InvalidRange("Window exceeds the maximum of " + maxWindow.String()))
+		return
+	}
+
+	loc, err := timeparam.Location(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at, hasAt, err := timeparam.Time(c, "at", now, loc)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !hasAt {
+		at = now
+	}
+	if at.After(now) {
+		_ = c.Error(quotes.InvalidRange("Invalid 'at' parameter: must not be in the future"))
+		return
+	}
+
+	average, err := h.action.Execute(c.Request.Context(), tokenName, method, currency, at, window)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := Response{
+		Token:            tokenName,
+		Method:           string(average.Method),
+		Currency:         string(average.Currency),
+		Value:            average.Value,
+		Points:           average.Points,
+		FilledPoints:     average.FilledPoints,
+		From:             format.Time(average.From),
+		To:               format.Time(average.To),
+		WindowSeconds:    int64(window / time.Second),
+		EffectiveFrom:    format.Time(average.EffectiveFrom),
+		EffectiveTo:      format.Time(average.EffectiveTo),
+		EffectiveSeconds: int64(average.Covered / time.Second),
+		GapSeconds:       int64((window - average.Covered) / time.Second),
+	}
+
+	// Averages over finalized windows never change
+	freshness := format.ForWindow(at, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	format.JSON(c, http.StatusOK, response, time.Time{}, freshness)
+}
+
+// parseWindow accepts Go durations (30m, 1h30m) and plain seconds
+func parseWindow(value string) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, seconds > 0
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil || window < time.Second {
+		return 0, false
+	}
+	return window.Truncate(time.Second), true
+}
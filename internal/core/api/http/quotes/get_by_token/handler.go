@@ -2,6 +2,10 @@ package get_by_token
 
 import (
 	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
 	"quotes/internal/core/application/quotes/get_by_token"
 	"strconv"
 	"time"
@@ -11,10 +15,11 @@ import (
 
 type Handler struct {
 	action *get_by_token.Action
+	config *config.Config
 }
 
-func New(action *get_by_token.Action) *Handler {
-	return &Handler{action: action}
+func New(action *get_by_token.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
 }
 
 // GetQuotesByToken godoc
@@ -22,76 +27,37 @@ func New(action *get_by_token.Action) *Handler {
 // @Description  Retrieve quotes for a specific token (mvrk, usdt, etc.) with optional filters. If no time range is specified, returns the latest 100 quotes by default.
 // @Tags         tokens
 // @Accept       json
-// @Produce      json
+// @Produce      json,application/msgpack,application/x-protobuf,application/vnd.apache.arrow.stream
 // @Param        token   path      string  true   "Token name (e.g., mvrk, usdt)"
-// @Param        from    query     string  false  "Start time (RFC3339 format, e.g., 2025-01-01T00:00:00Z). If not specified, returns latest quotes"
-// @Param        to      query     string  false  "End time (RFC3339 format, e.g., 2025-01-01T23:59:59Z). If not specified, returns latest quotes"
+// @Param        from    query     string  false  "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'. Without 'from' and 'to', returns latest quotes"
+// @Param        to      query     string  false  "End time, same formats as 'from'. Default: now"
+// @Param        tz      query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
 // @Param        limit   query     int     false  "Maximum number of quotes to return. Default: 100 when no time range specified, no limit when time range is specified"
 // @Success      200     {array}   quotes.Quote  "List of quotes"
-// @Failure      400     {object}  map[string]string  "Invalid request parameters"
-// @Failure      404     {object}  map[string]string  "Token not found"
-// @Failure      500     {object}  map[string]string  "Internal server error"
+// @Header       200     {string}  ETag           "Strong entity tag of the representation"
+// @Header       200     {string}  Last-Modified  "Timestamp of the newest quote in the response"
+// @Header       200     {string}  Cache-Control  "Long and immutable for finalized windows, short otherwise"
+// @Success      304     "Not modified (If-None-Match / If-Modified-Since matched)"
+// @Failure      400     {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404     {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND)"
+// @Failure      503     {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
 // @Router       /{token} [get]
 func (h *Handler) Handle(c *gin.Context) {
 	tokenName := c.Param("token")
 	if tokenName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Token name is required",
-		})
+		_ = c.Error(apierror.InvalidParameter("Token name is required"))
 		return
 	}
 
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
 	limitStr := c.Query("limit")
 
 	// Default limit when no time range is specified
 	const defaultLimit = 100
 
-	var from, to time.Time
-	var useTimeRange bool
-
-	if fromStr != "" {
-		if parsedFrom, err := time.Parse(time.RFC3339, fromStr); err == nil {
-			from = parsedFrom
-			useTimeRange = true
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid 'from' parameter format. Use RFC3339 format (e.g., 2023-01-01T00:00:00Z)",
-			})
-			return
-		}
-	}
-
-	if toStr != "" {
-		if parsedTo, err := time.Parse(time.RFC3339, toStr); err == nil {
-			to = parsedTo
-			useTimeRange = true
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid 'to' parameter format. Use RFC3339 format (e.g., 2023-01-01T00:00:00Z)",
-			})
-			return
-		}
-	}
-
-	// If time range is specified, validate it
-	if useTimeRange {
-		// If only one of from/to is specified, set defaults
-		now := time.Now()
-		if fromStr == "" {
-			from = now.Add(-24 * time.Hour)
-		}
-		if toStr == "" {
-			to = now
-		}
-
-		if from.After(to) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid time range: 'from' must be before 'to'",
-			})
-			return
-		}
+	timeRange, err := timeparam.ParseRange(c, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
 	limit := 0
@@ -99,32 +65,26 @@ func (h *Handler) Handle(c *gin.Context) {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
 			limit = parsedLimit
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid 'limit' parameter. Must be a positive integer",
-			})
+			_ = c.Error(apierror.InvalidParameter("Invalid 'limit' parameter. Must be a positive integer"))
 			return
 		}
 	}
 
-	// If no time range and no limit specified, use default limit
-	if !useTimeRange && limit == 0 {
+	// Without a time range the latest quotes are returned, by default the latest 100
+	var from, to time.Time
+	if timeRange.Explicit {
+		from, to = timeRange.From, timeRange.To
+	} else if limit == 0 {
 		limit = defaultLimit
 	}
 
-	quotes, err := h.action.Execute(c.Request.Context(), tokenName, from, to, limit)
+	quotesList, err := h.action.Execute(c.Request.Context(), tokenName, from, to, limit)
 	if err != nil {
-		if err == get_by_token.ErrTokenNotFound {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": "Token not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to get quotes",
-			"details": err.Error(),
-		})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, quotes)
+	// "Latest N" responses (no time range) always touch now; zero "to" gives the live max-age
+	freshness := format.ForWindow(to, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	format.Quotes(c, http.StatusOK, quotesList, freshness)
 }
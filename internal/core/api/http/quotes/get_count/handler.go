@@ -23,16 +23,13 @@ func New(action *get_count.Action) *Handler {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  map[string]int64  "Quote count"
-// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Failure      503  {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
 // @Router       /quotes/count [get]
 func (h *Handler) Handle(c *gin.Context) {
 	// Use mvrk token for /quotes/count endpoint
 	count, err := h.action.Execute(c.Request.Context(), string(quotes.TokenMVRK))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to get quotes count",
-			"details": err.Error(),
-		})
+		_ = c.Error(err)
 		return
 	}
 
@@ -0,0 +1,157 @@
+package get_daily
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/get_daily"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateFormat = "2006-01-02"
+
+type Handler struct {
+	action *get_daily.Action
+	config *config.Config
+}
+
+func New(action *get_daily.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response lists one price per local calendar day
+type Response struct {
+	Token    string  `json:"token" example:"mvrk"`
+	Currency string  `json:"currency" example:"krw"`
+	TZ       string  `json:"tz" example:"Asia/Seoul"`
+	Method   string  `json:"method" example:"close"`
+	From     string  `json:"from" example:"2025-01-01"` // First requested day
+	To       string  `json:"to" example:"2025-01-31"`   // Last requested day
+	Prices   []Price `json:"prices"`
+}
+
+// Price is the price of one local calendar day. Days without observed quotes are left out.
+type Price struct {
+	Date     string  `json:"date" example:"2025-01-01"`
+	Price    float64 `json:"price" example:"17.85"`
+	At       string  `json:"at,omitempty" example:"2025-01-01T14:59:00Z"` // Time of the quote the price comes from (open and close)
+	Points   int64   `json:"points" example:"1440"`                       // Observed quotes in the day
+	Start    string  `json:"start" example:"2024-12-31T15:00:00Z"`        // Start of the local day
+	End      string  `json:"end" example:"2025-01-01T15:00:00Z"`          // Start of the next local day
+	Complete bool    `json:"complete" example:"true"`                     // False while the day is still running
+}
+
+// GetDaily godoc
+// @Summary      Daily prices of a token in a local time zone
+// @Description  One price per calendar day in the time zone "tz": the close (last observed price), the open (first observed price) or the average of the day.
+// @Description  Days follow the local calendar, so they are 23 or 25 hours long across DST changes. Forward-filled prices are left out.
+// @Tags         tokens
+// @Produce      json
+// @Param        token     path      string  true   "Token name (e.g., mvrk, usdt)"
+// @Param        currency  query     string  false  "Currency (default usd)"
+// @Param        tz        query     string  false  "IANA time zone of the days (e.g., Asia/Seoul). Default: UTC"
+// @Param        method    query     string  false  "close, open or average. Default: daily.default_method"
+// @Param        from      query     string  false  "A time in the first day: RFC3339, date (2025-01-01, in tz), unix seconds/milliseconds or relative (now-30d). Default: 24 hours before 'to'"
+// @Param        to        query     string  false  "A time in the last day, same formats as 'from'. Default: now"
+// @Success      200       {object}  Response
+// @Failure      400       {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404       {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND)"
+// @Failure      503       {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /{token}/daily [get]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	currency := quotes.CurrencyUSD
+	if value := c.Query("currency"); value != "" {
+		if !quotes.IsCurrencySupported(value) {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'currency' parameter. Supported: " + format.SupportedCurrencies()))
+			return
+		}
+		currency = quotes.Currency(strings.ToLower(value))
+	}
+
+	method := quotes.DailyMethod(h.config.Daily.DefaultMethod)
+	if value := c.Query("method"); value != "" {
+		method = quotes.DailyMethod(strings.ToLower(value))
+	}
+	if !quotes.IsDailyMethodSupported(string(method)) {
+		_ = c.Error(apierror.InvalidParameter("Invalid 'method' parameter. Supported: close, open, average"))
+		return
+	}
+
+	loc, err := timeparam.Location(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	timeRange, err := timeparam.ParseRange(c, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	firstDay := quotes.StartOfDay(timeRange.From.In(loc))
+	lastDay := quotes.StartOfDay(timeRange.To.In(loc))
+	// Rounded since local days are not all 24 hours long
+	if days := int(math.Round(lastDay.Sub(firstDay).Hours()/24)) + 1; days > h.config.Daily.MaxDays {
+		_ = c.Error(quotes.InvalidRange(fmt.Sprintf("Range exceeds the maximum of %d days", h.config.Daily.MaxDays)))
+		return
+	}
+
+	prices, err := h.action.Execute(c.Request.Context(), tokenName, currency, loc, timeRange.From, timeRange.To)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := Response{
+		Token:    tokenName,
+		Currency: string(currency),
+		TZ:       loc.String(),
+		Method:   string(method),
+		From:     firstDay.Format(dateFormat),
+		To:       lastDay.Format(dateFormat),
+		Prices:   make([]Price, len(prices)),
+	}
+	var newest time.Time
+	for i, daily := range prices {
+		end := daily.End()
+		price := Price{
+			Date:     daily.Day.Format(dateFormat),
+			Price:    daily.Price(method),
+			Points:   daily.Points,
+			Start:    format.Time(daily.Day),
+			End:      format.Time(end),
+			Complete: !end.After(now),
+		}
+		switch method {
+		case quotes.DailyOpen:
+			price.At = format.Time(daily.OpenAt)
+		case quotes.DailyClose:
+			price.At = format.Time(daily.CloseAt)
+		}
+		if daily.CloseAt.After(newest) {
+			newest = daily.CloseAt
+		}
+		response.Prices[i] = price
+	}
+
+	// Days that ended before the finality horizon never change
+	end := quotes.StartOfDay(lastDay.AddDate(0, 0, 1))
+	freshness := format.ForWindow(end, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	format.JSON(c, http.StatusOK, response, newest, freshness)
+}
@@ -2,6 +2,8 @@ package get_latest
 
 import (
 	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
 	"quotes/internal/core/application/quotes/get_latest"
 	"quotes/internal/core/domain/quotes"
 
@@ -10,10 +12,11 @@ import (
 
 type Handler struct {
 	action *get_latest.Action
+	config *config.Config
 }
 
-func New(action *get_latest.Action) *Handler {
-	return &Handler{action: action}
+func New(action *get_latest.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
 }
 
 // GetLatestQuote godoc
@@ -21,20 +24,22 @@ func New(action *get_latest.Action) *Handler {
 // @Description  Retrieve the most recent quote for MVRK token
 // @Tags         quotes
 // @Accept       json
-// @Produce      json
+// @Produce      json,application/msgpack,application/x-protobuf,application/vnd.apache.arrow.stream
 // @Success      200  {object}  quotes.Quote  "Latest quote"
-// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Header       200  {string}  ETag           "Strong entity tag of the representation"
+// @Header       200  {string}  Last-Modified  "Timestamp of the newest quote in the response"
+// @Header       200  {string}  Cache-Control  "Long and immutable for finalized windows, short otherwise"
+// @Success      304  "Not modified (If-None-Match / If-Modified-Since matched)"
+// @Failure      404  {object}  apierror.Response  "No quotes stored yet (NO_DATA)"
+// @Failure      503  {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
 // @Router       /quotes/last [get]
 func (h *Handler) Handle(c *gin.Context) {
 	// Use mvrk token for /quotes/last endpoint (backward compatibility)
 	quote, err := h.action.Execute(c.Request.Context(), string(quotes.TokenMVRK))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to get latest quote",
-			"details": err.Error(),
-		})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, quote)
+	format.Quote(c, http.StatusOK, quote, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
 }
@@ -0,0 +1,140 @@
+package get_pair
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/get_pair"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *get_pair.Action
+	config *config.Config
+}
+
+func New(action *get_pair.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// GetPair godoc
+// @Summary      Synthetic pair between two tokens
+// @Description  Derives the price of the base token in the quote token (e.g., MVRK/USDT) by aligning both token tables on timestamp and dividing their prices in a pivot currency.
+// @Description  Every base quote is matched with the nearest quote token quote at most "tolerance" away; base quotes without a match are skipped.
+// @Description  Pagination and defaults are the same as GET /{token}: without 'from' and 'to', returns the latest quotes.
+// @Tags         tokens
+// @Produce      json,application/msgpack,application/x-protobuf,application/vnd.apache.arrow.stream
+// @Param        base       path      string  true   "Base token (e.g., mvrk)"
+// @Param        quote      path      string  true   "Quote token (e.g., usdt)"
+// @Param        pivot      query     string  false  "Currency both prices are taken in (default usd)"
+// @Param        tolerance  query     string  false  "Largest distance between aligned quotes, as a duration (30s, 1m) or seconds. Default: pairs.default_tolerance_seconds"
+// @Param        from       query     string  false  "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'. Without 'from' and 'to', returns latest quotes"
+// @Param        to         query     string  false  "End time, same formats as 'from'. Default: now"
+// @Param        tz         query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
+// @Param        limit      query     int     false  "Maximum number of quotes to return. Default: 100 when no time range specified, no limit when time range is specified"
+// @Success      200        {array}   quotes.PairQuote  "Pair quotes"
+// @Header       200        {string}  ETag           "Strong entity tag of the representation"
+// @Header       200        {string}  Last-Modified  "Timestamp of the newest quote in the response"
+// @Header       200        {string}  Cache-Control  "Long and immutable for finalized windows, short otherwise"
+// @Success      304        "Not modified (If-None-Match / If-Modified-Since matched)"
+// @Failure      400        {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404        {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND)"
+// @Failure      503        {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/pairs/{base}/{quote} [get]
+func (h *Handler) Handle(c *gin.Context) {
+	baseToken, quoteToken := strings.ToLower(c.Param("base")), strings.ToLower(c.Param("quote"))
+	for _, tokenName := range []string{baseToken, quoteToken} {
+		if !quotes.IsTokenSupported(tokenName) {
+			_ = c.Error(quotes.TokenNotFound(tokenName))
+			return
+		}
+	}
+	if baseToken == quoteToken {
+		_ = c.Error(apierror.InvalidParameter("Base and quote tokens must differ"))
+		return
+	}
+
+	pivot := quotes.CurrencyUSD
+	if value := c.Query("pivot"); value != "" {
+		if !quotes.IsCurrencySupported(value) {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'pivot' parameter. Use a supported currency (e.g., usd, eur, btc)"))
+			return
+		}
+		pivot = quotes.Currency(strings.ToLower(value))
+	}
+
+	tolerance := time.Duration(h.config.Pairs.DefaultToleranceSeconds) * time.Second
+	if value := c.Query("tolerance"); value != "" {
+		parsed, ok := parseTolerance(value)
+		if !ok {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'tolerance' parameter. Use a duration (e.g., 30s, 1m) or a number of seconds"))
+			return
+		}
+		tolerance = parsed
+	}
+	if maxTolerance := time.Duration(h.config.Pairs.MaxToleranceSeconds) * time.Second; tolerance > maxTolerance {
+		_ = c.Error(apierror.InvalidParameter("Tolerance exceeds the maximum of " + maxTolerance.String()))
+		return
+	}
+
+	// Default limit when no time range is specified
+	const defaultLimit = 100
+
+	timeRange, err := timeparam.ParseRange(c, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		} else {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'limit' parameter. Must be a positive integer"))
+			return
+		}
+	}
+
+	// Without a time range the latest quotes are returned, by default the latest 100
+	var from, to time.Time
+	if timeRange.Explicit {
+		from, to = timeRange.From, timeRange.To
+	} else if limit == 0 {
+		limit = defaultLimit
+	}
+
+	pairQuotes, err := h.action.Execute(c.Request.Context(), baseToken, quoteToken, pivot, tolerance, from, to, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// A window keeps changing until quote token quotes aligned with its end are final too;
+	// "latest N" responses (zero "to") get the live max-age
+	windowEnd := to
+	if !windowEnd.IsZero() {
+		windowEnd = windowEnd.Add(tolerance)
+	}
+	freshness := format.ForWindow(windowEnd, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	format.PairQuotes(c, http.StatusOK, pairQuotes, freshness)
+}
+
+// parseTolerance accepts Go durations (30s, 1m) and plain seconds
+func parseTolerance(value string) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, seconds >= 0
+	}
+	tolerance, err := time.ParseDuration(value)
+	if err != nil || tolerance < 0 {
+		return 0, false
+	}
+	return tolerance, true
+}
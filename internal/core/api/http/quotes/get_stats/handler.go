@@ -0,0 +1,126 @@
+package get_stats
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/get_stats"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *get_stats.Action
+	config *config.Config
+}
+
+func New(action *get_stats.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response describes the prices of a token over a range
+type Response struct {
+	Token    string `json:"token" example:"mvrk"`
+	Currency string `json:"currency" example:"usd"`
+	From     string `json:"from" example:"2025-01-01T00:00:00Z"`
+	To       string `json:"to" example:"2025-01-02T00:00:00Z"`
+	Count    int64  `json:"count" example:"1440"` // Observed quotes in the range; forward-filled prices are left out
+
+	Min    float64 `json:"min" example:"0.0118"`
+	MinAt  string  `json:"min_at" example:"2025-01-01T03:12:00Z"`
+	Max    float64 `json:"max" example:"0.0131"`
+	MaxAt  string  `json:"max_at" example:"2025-01-01T17:40:00Z"`
+	Mean   float64 `json:"mean" example:"0.0124"`
+	StdDev float64 `json:"stddev" example:"0.0003"` // Sample standard deviation
+
+	First         float64 `json:"first" example:"0.0120"`
+	FirstAt       string  `json:"first_at" example:"2025-01-01T00:00:00Z"`
+	Last          float64 `json:"last" example:"0.0126"`
+	LastAt        string  `json:"last_at" example:"2025-01-01T23:59:00Z"`
+	ChangePercent float64 `json:"change_percent" example:"5"` // Change from first to last quote
+
+	Volatility           float64 `json:"volatility" example:"0.042"`                               // Realized volatility over the range (square root of summed squared log returns)
+	AnnualizedVolatility float64 `json:"annualized_volatility" example:"0.80"`                     // Volatility scaled to a year
+	MaxDrawdown          float64 `json:"max_drawdown" example:"0.061"`                             // Largest drop from a running peak, as a fraction of the peak
+	MaxDrawdownAt        string  `json:"max_drawdown_at,omitempty" example:"2025-01-01T09:05:00Z"` // Time of the trough
+}
+
+// GetStats godoc
+// @Summary      Price statistics of a token over a range
+// @Description  Min, max, mean, standard deviation, change, realized volatility and max drawdown of the price in one currency.
+// @Description  Computed in the database over observed quotes; forward-filled prices are left out.
+// @Tags         tokens
+// @Produce      json
+// @Param        token     path      string  true   "Token name (e.g., mvrk, usdt)"
+// @Param        from      query     string  false  "Start time: RFC3339, date (2025-01-01), unix seconds/milliseconds or relative (-24h, now-7d). Default: 24 hours before 'to'"
+// @Param        to        query     string  false  "End time, same formats as 'from'. Default: now"
+// @Param        tz        query     string  false  "IANA time zone for dates without an offset (e.g., Europe/Paris). Default: UTC"
+// @Param        currency  query     string  false  "Currency (default usd)"
+// @Success      200       {object}  Response
+// @Failure      400       {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER, INVALID_RANGE)"
+// @Failure      404       {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND) or no quotes in the range (NO_DATA)"
+// @Failure      503       {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /{token}/stats [get]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	currency := quotes.CurrencyUSD
+	if value := c.Query("currency"); value != "" {
+		if !quotes.IsCurrencySupported(value) {
+			_ = c.Error(apierror.InvalidParameter("Invalid 'currency' parameter. Supported: " + format.SupportedCurrencies()))
+			return
+		}
+		currency = quotes.Currency(strings.ToLower(value))
+	}
+
+	timeRange, err := timeparam.ParseRange(c, time.Now().Truncate(time.Second))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	from, to := timeRange.From, timeRange.To
+
+	stats, err := h.action.Execute(c.Request.Context(), tokenName, currency, from, to)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := Response{
+		Token:                tokenName,
+		Currency:             string(stats.Currency),
+		From:                 format.Time(from),
+		To:                   format.Time(to),
+		Count:                stats.Count,
+		Min:                  stats.Min,
+		MinAt:                format.Time(stats.MinAt),
+		Max:                  stats.Max,
+		MaxAt:                format.Time(stats.MaxAt),
+		Mean:                 stats.Mean,
+		StdDev:               stats.StdDev,
+		First:                stats.First,
+		FirstAt:              format.Time(stats.FirstAt),
+		Last:                 stats.Last,
+		LastAt:               format.Time(stats.LastAt),
+		ChangePercent:        stats.ChangePercent(),
+		Volatility:           stats.Volatility,
+		AnnualizedVolatility: stats.AnnualizedVolatility(),
+		MaxDrawdown:          stats.MaxDrawdown,
+	}
+	if !stats.MaxDrawdownAt.IsZero() {
+		response.MaxDrawdownAt = format.Time(stats.MaxDrawdownAt)
+	}
+
+	// Statistics over finalized ranges never change
+	freshness := format.ForWindow(to, h.config.GetFinalityHorizon(), h.config.GetCacheMaxAge())
+	format.JSON(c, http.StatusOK, response, stats.LastAt, freshness)
+}
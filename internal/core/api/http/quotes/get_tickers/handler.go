@@ -0,0 +1,111 @@
+package get_tickers
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/application/quotes/get_tickers"
+	"quotes/internal/core/domain/quotes"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	action *get_tickers.Action
+	config *config.Config
+}
+
+func New(action *get_tickers.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Response lists the tickers of all enabled tokens
+type Response struct {
+	Tickers []Ticker `json:"tickers"`
+}
+
+// Ticker summarizes the latest price of a token
+type Ticker struct {
+	Token      string                    `json:"token" example:"mvrk"`
+	Timestamp  string                    `json:"timestamp" example:"2025-01-01T12:00:00Z"` // Time of the latest quote
+	AgeSeconds int64                     `json:"age_seconds" example:"42"`                 // Age of the latest quote
+	Currencies map[string]CurrencyTicker `json:"currencies"`                               // Keyed by currency (btc, usd, ...)
+}
+
+// CurrencyTicker is the latest price in one currency and its changes per period (1h, 24h, 7d).
+// A change is null when there is no quote that far back.
+type CurrencyTicker struct {
+	Price         float64             `json:"price" example:"0.0123"`
+	Change        map[string]*float64 `json:"change"`
+	ChangePercent map[string]*float64 `json:"change_percent"`
+}
+
+// GetTickers godoc
+// @Summary      Tickers of all enabled tokens
+// @Description  Latest price of every enabled token in each currency, with absolute and percentage changes over 1h, 24h and 7d.
+// @Description  Changes compare the latest quote with the last quote at or before the same time 1h, 24h or 7d earlier.
+// @Description  Tokens without quotes yet are left out.
+// @Tags         tokens
+// @Produce      json
+// @Success      200  {object}  Response
+// @Failure      503  {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/tickers [get]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenNames := make([]string, 0)
+	for _, tokenName := range quotes.GetSupportedTokenNames() {
+		if h.config.IsTokenEnabled(tokenName) {
+			tokenNames = append(tokenNames, tokenName)
+		}
+	}
+	sort.Strings(tokenNames)
+
+	tickers, err := h.action.Execute(c.Request.Context(), tokenNames)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	response := Response{Tickers: make([]Ticker, len(tickers))}
+	var newest time.Time
+	for i, ticker := range tickers {
+		response.Tickers[i] = toResponse(ticker, now)
+		if ticker.Latest.Timestamp.After(newest) {
+			newest = ticker.Latest.Timestamp
+		}
+	}
+
+	format.JSON(c, http.StatusOK, response, newest, format.Freshness{MaxAge: h.config.GetCacheMaxAge()})
+}
+
+func toResponse(ticker quotes.Ticker, now time.Time) Ticker {
+	result := Ticker{
+		Token:      ticker.Token,
+		Timestamp:  format.Time(ticker.Latest.Timestamp),
+		AgeSeconds: int64(now.Sub(ticker.Latest.Timestamp) / time.Second),
+		Currencies: make(map[string]CurrencyTicker),
+	}
+
+	for _, currency := range quotes.GetSupportedCurrencies() {
+		currencyTicker := CurrencyTicker{
+			Price:         ticker.Latest.Price(currency),
+			Change:        make(map[string]*float64, len(quotes.TickerPeriods)),
+			ChangePercent: make(map[string]*float64, len(quotes.TickerPeriods)),
+		}
+		for _, period := range quotes.TickerPeriods {
+			change, percent, ok := ticker.Change(currency, period.Name)
+			if !ok {
+				currencyTicker.Change[period.Name] = nil
+				currencyTicker.ChangePercent[period.Name] = nil
+				continue
+			}
+			currencyTicker.Change[period.Name] = &change
+			currencyTicker.ChangePercent[period.Name] = &percent
+		}
+		result.Currencies[string(currency)] = currencyTicker
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/middleware"
+	"quotes/internal/core/application/quotes/get_by_token"
+	"quotes/internal/core/infrastructure/pubsub"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type Handler struct {
+	hub      *pubsub.Hub
+	action   *get_by_token.Action
+	config   *config.Config
+	upgrader websocket.Upgrader
+}
+
+func New(hub *pubsub.Hub, action *get_by_token.Action, cfg *config.Config) *Handler {
+	origins := middleware.NewOriginMatcher(cfg)
+	return &Handler{
+		hub:    hub,
+		action: action,
+		config: cfg,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			// Browsers send Origin on WebSocket upgrades without enforcing CORS, so the allowed origins
+			// are checked here; clients that are not browsers send no Origin and are accepted
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origins.Allowed(origin)
+			},
+		},
+	}
+}
+
+// StreamQuotes godoc
+// @Summary      Live quote stream (WebSocket)
+// @Description  Upgrades to a WebSocket that pushes every new quote as soon as it is saved.
+// @Description  Client messages: {"action":"subscribe","tokens":["mvrk"],"currencies":["usd"],"since":"2025-01-01T00:00:00Z"} and {"action":"unsubscribe","tokens":["mvrk"]}.
+// @Description  Server messages have a "type": quote, subscribed, unsubscribed, replay_truncated, heartbeat or error.
+// @Description  "since" replays the quotes saved after that time before live ones. Clients that fall behind are disconnected with close code 1013.
+// @Tags         stream
+// @Param        tokens      query  string  false  "Comma-separated tokens to subscribe to on connect (e.g., mvrk,usdt)"
+// @Param        currencies  query  string  false  "Comma-separated currencies to include (default: all)"
+// @Param        since       query  string  false  "Resume point (any time parameter format, e.g. RFC3339 or -1h); quotes saved after it are replayed first"
+// @Success      101  "Switching protocols"
+// @Failure      400  {object}  apierror.Response  "Invalid request parameters (INVALID_PARAMETER)"
+// @Router       /v1/stream [get]
+func (h *Handler) Handle(c *gin.Context) {
+	initial := clientMessage{
+		Action:     actionSubscribe,
+		Tokens:     splitList(c.Query("tokens")),
+		Currencies: splitList(c.Query("currencies")),
+		Since:      c.Query("since"),
+	}
+	if _, _, _, err := validate(initial); err != nil {
+		_ = c.Error(apierror.InvalidParameter(err.Error()))
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already answered with an HTTP error
+		return
+	}
+
+	s := newSession(conn, h.hub.Subscribe(nil, h.config.Stream.BufferSize), h.action, h.config)
+	s.run(c.Request.Context(), initial)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
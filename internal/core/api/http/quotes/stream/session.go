@@ -0,0 +1,333 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/get_by_token"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/pubsub"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait bounds a single write to the client
+	writeWait = 10 * time.Second
+	// maxMessageSize bounds client messages (subscriptions are tiny)
+	maxMessageSize = 4096
+)
+
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+)
+
+const (
+	typeQuote           = "quote"
+	typeSubscribed      = "subscribed"
+	typeUnsubscribed    = "unsubscribed"
+	typeReplayTruncated = "replay_truncated"
+	typeHeartbeat       = "heartbeat"
+	typeError           = "error"
+)
+
+type clientMessage struct {
+	Action     string   `json:"action"`
+	Tokens     []string `json:"tokens"`
+	Currencies []string `json:"currencies"`
+	Since      string   `json:"since"`
+}
+
+type serverMessage struct {
+	Type       string             `json:"type"`
+	Token      string             `json:"token,omitempty"`
+	Timestamp  string             `json:"timestamp,omitempty"`
+	Prices     map[string]float64 `json:"prices,omitempty"`
+	Tokens     []string           `json:"tokens,omitempty"`
+	Currencies []string           `json:"currencies,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// session serves one WebSocket connection. Only the run loop writes to the connection;
+// the read loop forwards client messages to it.
+type session struct {
+	conn      *websocket.Conn
+	sub       *pubsub.Subscription
+	action    *get_by_token.Action
+	heartbeat time.Duration
+	maxReplay int
+
+	tokens     map[string]bool
+	currencies []quotes.Currency
+	lastSent   map[string]time.Time
+}
+
+func newSession(conn *websocket.Conn, sub *pubsub.Subscription, action *get_by_token.Action, cfg *config.Config) *session {
+	return &session{
+		conn:       conn,
+		sub:        sub,
+		action:     action,
+		heartbeat:  cfg.GetStreamHeartbeat(),
+		maxReplay:  cfg.Stream.MaxReplay,
+		tokens:     make(map[string]bool),
+		currencies: quotes.GetSupportedCurrencies(),
+		lastSent:   make(map[string]time.Time),
+	}
+}
+
+func (s *session) run(ctx context.Context, initial clientMessage) {
+	defer s.conn.Close()
+	defer s.sub.Close()
+
+	messages := make(chan clientMessage)
+	readerDone := make(chan struct{})
+	writerDone := make(chan struct{})
+	defer close(writerDone)
+
+	go s.readLoop(messages, readerDone, writerDone)
+
+	if len(initial.Tokens) > 0 || len(initial.Currencies) > 0 {
+		if err := s.handle(ctx, initial); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-readerDone:
+			return
+		case <-ctx.Done():
+			s.close(websocket.CloseGoingAway, "server shutting down")
+			return
+		case msg := <-messages:
+			if err := s.handle(ctx, msg); err != nil {
+				return
+			}
+		case event, ok := <-s.sub.Events():
+			if !ok {
+				if s.sub.Dropped() {
+					s.close(websocket.CloseTryAgainLater, "client too slow")
+				}
+				return
+			}
+			if err := s.sendQuote(event.TokenName, event.Quote); err != nil {
+				return
+			}
+		case now := <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, now.Add(writeWait)); err != nil {
+				return
+			}
+			if err := s.send(serverMessage{Type: typeHeartbeat, Timestamp: format.Time(now)}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readLoop reads client messages until the connection fails or goes silent for two heartbeats
+func (s *session) readLoop(messages chan<- clientMessage, readerDone, writerDone chan struct{}) {
+	defer close(readerDone)
+
+	s.conn.SetReadLimit(maxMessageSize)
+	extend := func() error {
+		return s.conn.SetReadDeadline(time.Now().Add(2 * s.heartbeat))
+	}
+	_ = extend()
+	s.conn.SetPongHandler(func(string) error { return extend() })
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg clientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			msg = clientMessage{}
+		}
+		_ = extend()
+
+		select {
+		case messages <- msg:
+		case <-writerDone:
+			return
+		}
+	}
+}
+
+func (s *session) handle(ctx context.Context, msg clientMessage) error {
+	switch msg.Action {
+	case actionSubscribe:
+		return s.subscribe(ctx, msg)
+	case actionUnsubscribe:
+		return s.unsubscribe(msg)
+	case "":
+		return s.sendError(errors.New("malformed message, expected a JSON object with an action"))
+	default:
+		return s.sendError(fmt.Errorf("unknown action %q, expected %q or %q", msg.Action, actionSubscribe, actionUnsubscribe))
+	}
+}
+
+func (s *session) subscribe(ctx context.Context, msg clientMessage) error {
+	tokens, currencies, since, err := validate(msg)
+	if err != nil {
+		return s.sendError(err)
+	}
+
+	if len(currencies) > 0 {
+		s.currencies = currencies
+	}
+
+	// Subscribe before replaying so quotes saved during the replay are not missed;
+	// duplicates are skipped by sendQuote
+	s.sub.AddTokens(tokens)
+	for _, token := range tokens {
+		s.tokens[token] = true
+	}
+
+	if err := s.send(serverMessage{Type: typeSubscribed, Tokens: s.sub.Tokens(), Currencies: currencyNames(s.currencies)}); err != nil {
+		return err
+	}
+
+	if since.IsZero() {
+		return nil
+	}
+	for _, token := range tokens {
+		if err := s.replay(ctx, token, since); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *session) unsubscribe(msg clientMessage) error {
+	tokens := make([]string, len(msg.Tokens))
+	for i, token := range msg.Tokens {
+		tokens[i] = strings.ToLower(token)
+	}
+
+	s.sub.RemoveTokens(tokens)
+	for _, token := range tokens {
+		delete(s.tokens, token)
+		delete(s.lastSent, token)
+	}
+
+	return s.send(serverMessage{Type: typeUnsubscribed, Tokens: tokens})
+}
+
+// replay sends the quotes saved after since, up to maxReplay of them
+func (s *session) replay(ctx context.Context, token string, since time.Time) error {
+	quotesList, err := s.action.Execute(ctx, token, since, time.Now(), s.maxReplay+1)
+	if err != nil {
+		log.Printf("Error replaying quotes for %s: %v", token, err)
+		return s.sendError(fmt.Errorf("failed to replay quotes for %s", token))
+	}
+
+	truncated := len(quotesList) > s.maxReplay
+	if truncated {
+		quotesList = quotesList[:s.maxReplay]
+	}
+
+	for _, quote := range quotesList {
+		if !quote.Timestamp.After(since) {
+			continue
+		}
+		if err := s.sendQuote(token, quote); err != nil {
+			return err
+		}
+	}
+
+	if truncated {
+		// The client has to fetch the gap through the REST API
+		return s.send(serverMessage{Type: typeReplayTruncated, Token: token, Timestamp: format.Time(s.lastSent[token])})
+	}
+	return nil
+}
+
+// sendQuote sends a quote unless the token was unsubscribed or the quote was already sent
+func (s *session) sendQuote(token string, quote quotes.Quote) error {
+	if !s.tokens[token] || !quote.Timestamp.After(s.lastSent[token]) {
+		return nil
+	}
+
+	prices := make(map[string]float64, len(s.currencies))
+	for _, currency := range s.currencies {
+		prices[string(currency)] = quote.Price(currency)
+	}
+
+	if err := s.send(serverMessage{Type: typeQuote, Token: token, Timestamp: format.Time(quote.Timestamp), Prices: prices}); err != nil {
+		return err
+	}
+	s.lastSent[token] = quote.Timestamp
+	return nil
+}
+
+func (s *session) sendError(err error) error {
+	return s.send(serverMessage{Type: typeError, Error: err.Error()})
+}
+
+func (s *session) send(msg serverMessage) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(msg)
+}
+
+func (s *session) close(code int, reason string) {
+	_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
+
+// validate checks a subscription request, returning normalized tokens and currencies and the resume point
+func validate(msg clientMessage) ([]string, []quotes.Currency, time.Time, error) {
+	tokens := make([]string, 0, len(msg.Tokens))
+	for _, token := range msg.Tokens {
+		token = strings.ToLower(token)
+		if !quotes.IsTokenSupported(token) {
+			return nil, nil, time.Time{}, fmt.Errorf("token '%s' is not supported", token)
+		}
+		tokens = append(tokens, token)
+	}
+
+	supported := make(map[quotes.Currency]bool)
+	for _, currency := range quotes.GetSupportedCurrencies() {
+		supported[currency] = true
+	}
+	currencies := make([]quotes.Currency, 0, len(msg.Currencies))
+	for _, name := range msg.Currencies {
+		currency := quotes.Currency(strings.ToLower(name))
+		if !supported[currency] {
+			return nil, nil, time.Time{}, fmt.Errorf("currency '%s' is not supported", name)
+		}
+		currencies = append(currencies, currency)
+	}
+
+	var since time.Time
+	if msg.Since != "" {
+		parsed, err := timeparam.Parse(msg.Since, time.Now(), time.UTC)
+		if err != nil {
+			return nil, nil, time.Time{}, fmt.Errorf("invalid 'since' format, use %s", timeparam.Formats)
+		}
+		since = parsed
+	}
+
+	return tokens, currencies, since, nil
+}
+
+func currencyNames(currencies []quotes.Currency) []string {
+	names := make([]string, len(currencies))
+	for i, currency := range currencies {
+		names[i] = string(currency)
+	}
+	return names
+}
@@ -0,0 +1,295 @@
+package valuate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"quotes/internal/config"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/api/http/format"
+	"quotes/internal/core/api/http/timeparam"
+	"quotes/internal/core/application/quotes/valuate"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const mimeCSV = "text/csv"
+
+// Bodies are limited to bytesPerEntry for each of valuations.max_entries entries, plus bodyOverhead
+const (
+	bytesPerEntry = 256
+	bodyOverhead  = 4096
+)
+
+type Handler struct {
+	action *valuate.Action
+	config *config.Config
+}
+
+func New(action *valuate.Action, cfg *config.Config) *Handler {
+	return &Handler{action: action, config: cfg}
+}
+
+// Request is the JSON body of a valuation request
+type Request struct {
+	Currencies []string `json:"currencies" example:"usd,eur"` // Overrides the currencies query parameter
+	Entries    []Entry  `json:"entries"`
+}
+
+// Entry is an amount of the token at a moment
+type Entry struct {
+	Timestamp json.RawMessage `json:"timestamp" swaggertype:"string" example:"2025-01-01T12:00:00Z"` // Any time parameter format, or unix seconds as a number
+	Amount    float64         `json:"amount" example:"12.5"`
+}
+
+// Response is the valued entries with totals
+type Response struct {
+	Token        string             `json:"token" example:"mvrk"`
+	Currencies   []string           `json:"currencies" example:"usd,eur"`
+	Count        int                `json:"count" example:"2"`
+	TotalAmount  float64            `json:"total_amount" example:"25"`
+	Totals       map[string]float64 `json:"totals"`                    // Sum of values per currency over entries with a price in it
+	StaleCount   int                `json:"stale_count" example:"0"`   // Entries whose quote is stale or missing
+	MissingCount int                `json:"missing_count" example:"0"` // Entries without any quote at or before their timestamp
+	Rows         []Row              `json:"rows"`
+}
+
+// Row is one valued entry, in request order
+type Row struct {
+	Timestamp       string             `json:"timestamp" example:"2025-01-01T12:00:00Z"`
+	Amount          float64            `json:"amount" example:"12.5"`
+	QuoteTimestamp  string             `json:"quote_timestamp,omitempty" example:"2025-01-01T11:59:00Z"` // Last quote at or before the timestamp
+	QuoteAgeSeconds int64              `json:"quote_age_seconds" example:"60"`
+	Stale           bool               `json:"stale" example:"false"` // The quote is older than valuations.max_quote_age_seconds, forward-filled, missing or lacks a currency
+	Values          map[string]float64 `json:"values"`
+	// Requested currencies the quote has no price in, left out of values and totals
+	MissingCurrencies []string `json:"missing_currencies,omitempty" example:"gbp"`
+}
+
+// Valuate godoc
+// @Summary      Value timestamped amounts of a token in fiat
+// @Description  Values every (timestamp, amount) entry with the last quote of the token at or before the timestamp, in one database query.
+// @Description  The body is JSON ({"entries": [{"timestamp", "amount"}], "currencies": [...]}) or CSV (Content-Type text/csv, columns timestamp,amount, optional header).
+// @Description  Entries whose quote is older than valuations.max_quote_age_seconds or forward-filled in a requested currency are flagged as stale but still valued; entries without any quote are flagged and not valued.
+// @Description  A requested currency the quote has no price in is listed in the row's missing_currencies, left out of its values and totals, and flags the row as stale.
+// @Tags         tokens
+// @Accept       json,text/csv
+// @Produce      json
+// @Param        token       path      string   true   "Token name (e.g., mvrk, usdt)"
+// @Param        currencies  query     string   false  "Comma-separated currencies (default usd)"
+// @Param        tz          query     string   false  "IANA time zone for timestamps without an offset (e.g., Europe/Paris). Default: UTC"
+// @Param        request     body      Request  true   "Entries to value"
+// @Success      200         {object}  Response
+// @Failure      400         {object}  apierror.Response  "Invalid body or parameters (INVALID_PARAMETER)"
+// @Failure      404         {object}  apierror.Response  "Token not found (TOKEN_NOT_FOUND)"
+// @Failure      413         {object}  apierror.Response  "Body larger than 256 bytes per allowed entry (REQUEST_TOO_LARGE)"
+// @Failure      503         {object}  apierror.Response  "Database unavailable (UPSTREAM_UNAVAILABLE)"
+// @Router       /v1/tokens/{token}/valuations [post]
+func (h *Handler) Handle(c *gin.Context) {
+	tokenName := strings.ToLower(c.Param("token"))
+	if !quotes.IsTokenSupported(tokenName) {
+		_ = c.Error(quotes.TokenNotFound(tokenName))
+		return
+	}
+
+	loc, err := timeparam.Location(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	maxBytes := int64(h.config.Valuations.MaxEntries)*bytesPerEntry + bodyOverhead
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+
+	currencyNames := strings.Split(c.DefaultQuery("currencies", string(quotes.CurrencyUSD)), ",")
+	var entries []quotes.ValuationEntry
+	if c.ContentType() == mimeCSV {
+		entries, err = h.readCSV(body, loc)
+	} else {
+		var names []string
+		entries, names, err = h.readJSON(body, loc)
+		if len(names) > 0 {
+			currencyNames = names
+		}
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if len(entries) == 0 {
+		_ = c.Error(apierror.InvalidParameter("No entries to value"))
+		return
+	}
+
+	currencies, err := parseCurrencies(currencyNames)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	report, err := h.action.Execute(c.Request.Context(), tokenName, entries, currencies)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toResponse(tokenName, currencies, report))
+}
+
+func (h *Handler) readJSON(body io.Reader, loc *time.Location) ([]quotes.ValuationEntry, []string, error) {
+	var request Request
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		if tooLarge := bodyTooLarge(err); tooLarge != nil {
+			return nil, nil, tooLarge
+		}
+		return nil, nil, apierror.InvalidParameter("Invalid JSON body: " + err.Error())
+	}
+	if len(request.Entries) > h.config.Valuations.MaxEntries {
+		return nil, nil, tooManyEntries(h.config.Valuations.MaxEntries)
+	}
+
+	now := time.Now()
+	entries := make([]quotes.ValuationEntry, len(request.Entries))
+	for i, entry := range request.Entries {
+		value := string(entry.Timestamp)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		entry, err := newEntry(i+1, value, entry.Amount, now, loc)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, request.Currencies, nil
+}
+
+func (h *Handler) readCSV(body io.Reader, loc *time.Location) ([]quotes.ValuationEntry, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	now := time.Now()
+	var entries []quotes.ValuationEntry
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if tooLarge := bodyTooLarge(err); tooLarge != nil {
+				return nil, tooLarge
+			}
+			return nil, apierror.InvalidParameter("Invalid CSV body: " + err.Error())
+		}
+		// Optional header
+		if line == 1 && strings.EqualFold(record[0], "timestamp") {
+			continue
+		}
+		if len(entries) == h.config.Valuations.MaxEntries {
+			return nil, tooManyEntries(h.config.Valuations.MaxEntries)
+		}
+
+		amount, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, apierror.InvalidParameter(fmt.Sprintf("Invalid amount on line %d", line))
+		}
+		entry, err := newEntry(line, record[0], amount, now, loc)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func newEntry(row int, timestamp string, amount float64, now time.Time, loc *time.Location) (quotes.ValuationEntry, error) {
+	parsed, err := timeparam.Parse(timestamp, now, loc)
+	if err != nil {
+		return quotes.ValuationEntry{}, apierror.InvalidParameter(fmt.Sprintf("Invalid timestamp in entry %d. Use %s", row, timeparam.Formats))
+	}
+	if math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return quotes.ValuationEntry{}, apierror.InvalidParameter(fmt.Sprintf("Invalid amount in entry %d", row))
+	}
+	return quotes.ValuationEntry{Timestamp: parsed, Amount: amount}, nil
+}
+
+func parseCurrencies(names []string) ([]quotes.Currency, error) {
+	currencies := make([]quotes.Currency, 0, len(names))
+	seen := make(map[quotes.Currency]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !quotes.IsCurrencySupported(name) {
+			return nil, apierror.InvalidParameter(fmt.Sprintf("Invalid currency '%s'", name))
+		}
+		currency := quotes.Currency(strings.ToLower(name))
+		if !seen[currency] {
+			seen[currency] = true
+			currencies = append(currencies, currency)
+		}
+	}
+	return currencies, nil
+}
+
+func tooManyEntries(maxEntries int) error {
+	return apierror.InvalidParameter(fmt.Sprintf("Too many entries, at most %d can be valued in one request", maxEntries))
+}
+
+// bodyTooLarge returns the error sent when err comes from a body over the size limit, nil otherwise
+func bodyTooLarge(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		return nil
+	}
+	return apierror.New(http.StatusRequestEntityTooLarge, apierror.CodeRequestTooLarge,
+		fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit))
+}
+
+func toResponse(tokenName string, currencies []quotes.Currency, report quotes.ValuationReport) Response {
+	response := Response{
+		Token:        tokenName,
+		Currencies:   make([]string, len(currencies)),
+		Count:        len(report.Valuations),
+		TotalAmount:  report.TotalAmount,
+		Totals:       make(map[string]float64, len(report.Totals)),
+		StaleCount:   report.StaleCount,
+		MissingCount: report.MissingCount,
+		Rows:         make([]Row, len(report.Valuations)),
+	}
+	for i, currency := range currencies {
+		response.Currencies[i] = string(currency)
+	}
+	for currency, total := range report.Totals {
+		response.Totals[string(currency)] = total
+	}
+
+	for i, valuation := range report.Valuations {
+		row := Row{
+			Timestamp: format.Time(valuation.Timestamp),
+			Amount:    valuation.Amount,
+			Stale:     valuation.Stale,
+			Values:    make(map[string]float64, len(valuation.Values)),
+		}
+		if valuation.HasQuote() {
+			row.QuoteTimestamp = format.Time(valuation.Quote.Timestamp)
+			row.QuoteAgeSeconds = int64(valuation.QuoteAge() / time.Second)
+		}
+		for currency, value := range valuation.Values {
+			row.Values[string(currency)] = value
+		}
+		for _, currency := range valuation.MissingCurrencies {
+			row.MissingCurrencies = append(row.MissingCurrencies, string(currency))
+		}
+		response.Rows[i] = row
+	}
+
+	return response
+}
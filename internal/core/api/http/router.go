@@ -1,10 +1,25 @@
 package http
 
 import (
+	"quotes/internal/core/api/graphql"
+	"quotes/internal/core/api/http/oracle/get_attestation"
+	"quotes/internal/core/api/http/oracle/get_candles"
+	"quotes/internal/core/api/http/oracle/get_feed_info"
+	"quotes/internal/core/api/http/oracle/get_key"
+	"quotes/internal/core/api/http/proofs/get_proof"
+	"quotes/internal/core/api/http/quotes/convert"
+	"quotes/internal/core/api/http/quotes/events"
 	"quotes/internal/core/api/http/quotes/get_all"
+	"quotes/internal/core/api/http/quotes/get_average"
 	"quotes/internal/core/api/http/quotes/get_by_token"
 	"quotes/internal/core/api/http/quotes/get_count"
+	"quotes/internal/core/api/http/quotes/get_daily"
 	"quotes/internal/core/api/http/quotes/get_latest"
+	"quotes/internal/core/api/http/quotes/get_pair"
+	"quotes/internal/core/api/http/quotes/get_stats"
+	"quotes/internal/core/api/http/quotes/get_tickers"
+	"quotes/internal/core/api/http/quotes/stream"
+	"quotes/internal/core/api/http/quotes/valuate"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -12,10 +27,25 @@ import (
 )
 
 type Router struct {
-	getLatestHandler  *get_latest.Handler
-	getCountHandler   *get_count.Handler
-	getAllHandler     *get_all.Handler
-	getByTokenHandler *get_by_token.Handler
+	getLatestHandler   *get_latest.Handler
+	getCountHandler    *get_count.Handler
+	getAllHandler      *get_all.Handler
+	getByTokenHandler  *get_by_token.Handler
+	streamHandler      *stream.Handler
+	eventsHandler      *events.Handler
+	graphqlHandler     *graphql.Handler
+	averageHandler     *get_average.Handler
+	statsHandler       *get_stats.Handler
+	tickersHandler     *get_tickers.Handler
+	dailyHandler       *get_daily.Handler
+	convertHandler     *convert.Handler
+	valuateHandler     *valuate.Handler
+	pairHandler        *get_pair.Handler
+	attestationHandler *get_attestation.Handler
+	oracleKeyHandler   *get_key.Handler
+	candlesHandler     *get_candles.Handler
+	feedInfoHandler    *get_feed_info.Handler
+	proofHandler       *get_proof.Handler
 }
 
 func NewRouter(
@@ -23,12 +53,42 @@ func NewRouter(
 	getCountHandler *get_count.Handler,
 	getAllHandler *get_all.Handler,
 	getByTokenHandler *get_by_token.Handler,
+	streamHandler *stream.Handler,
+	eventsHandler *events.Handler,
+	graphqlHandler *graphql.Handler,
+	averageHandler *get_average.Handler,
+	statsHandler *get_stats.Handler,
+	tickersHandler *get_tickers.Handler,
+	dailyHandler *get_daily.Handler,
+	convertHandler *convert.Handler,
+	valuateHandler *valuate.Handler,
+	pairHandler *get_pair.Handler,
+	attestationHandler *get_attestation.Handler,
+	oracleKeyHandler *get_key.Handler,
+	candlesHandler *get_candles.Handler,
+	feedInfoHandler *get_feed_info.Handler,
+	proofHandler *get_proof.Handler,
 ) *Router {
 	return &Router{
-		getLatestHandler:  getLatestHandler,
-		getCountHandler:   getCountHandler,
-		getAllHandler:     getAllHandler,
-		getByTokenHandler: getByTokenHandler,
+		getLatestHandler:   getLatestHandler,
+		getCountHandler:    getCountHandler,
+		getAllHandler:      getAllHandler,
+		getByTokenHandler:  getByTokenHandler,
+		streamHandler:      streamHandler,
+		eventsHandler:      eventsHandler,
+		graphqlHandler:     graphqlHandler,
+		averageHandler:     averageHandler,
+		statsHandler:       statsHandler,
+		tickersHandler:     tickersHandler,
+		dailyHandler:       dailyHandler,
+		convertHandler:     convertHandler,
+		valuateHandler:     valuateHandler,
+		pairHandler:        pairHandler,
+		attestationHandler: attestationHandler,
+		oracleKeyHandler:   oracleKeyHandler,
+		candlesHandler:     candlesHandler,
+		feedInfoHandler:    feedInfoHandler,
+		proofHandler:       proofHandler,
 	}
 }
 
@@ -51,6 +111,10 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 		})
 	})
 
+	// GraphQL (GET for persisted queries and CDN caching, POST for everything)
+	engine.GET("/graphql", r.graphqlHandler.Handle)
+	engine.POST("/graphql", r.graphqlHandler.Handle)
+
 	v1 := engine.Group("/")
 	{
 		quotes := v1.Group("/quotes")
@@ -60,7 +124,52 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 			quotes.GET("/count", r.getCountHandler.Handle)
 		}
 
+		// Live quotes over WebSocket
+		v1.GET("/v1/stream", r.streamHandler.Handle)
+
+		// Latest prices and changes of all enabled tokens
+		v1.GET("/v1/tickers", r.tickersHandler.Handle)
+
+		// Amount conversion between tokens and currencies
+		v1.GET("/v1/convert", r.convertHandler.Handle)
+
+		// Point-in-time valuation of timestamped amounts (JSON or CSV)
+		v1.POST("/v1/tokens/:token/valuations", r.valuateHandler.Handle)
+
+		// Synthetic cross pairs between stored tokens (e.g., MVRK/USDT)
+		v1.GET("/v1/pairs/:base/:quote", r.pairHandler.Handle)
+
+		// Signed price attestations for contracts (only when an oracle key is configured)
+		if r.attestationHandler != nil {
+			v1.GET("/v1/oracle/key", r.oracleKeyHandler.Handle)
+			v1.GET("/v1/oracle/:token", r.attestationHandler.Handle)
+		}
+
+		// Harbinger-compatible candle feed (only when assets and an oracle key are configured)
+		if r.candlesHandler != nil {
+			v1.GET("/v1/harbinger/oracle", r.candlesHandler.Handle)
+			v1.GET("/v1/harbinger/info", r.feedInfoHandler.Handle)
+		}
+
+		// Inclusion proofs against the daily Merkle commitments (only when proofs are enabled)
+		if r.proofHandler != nil {
+			v1.GET("/v1/proofs/:token", r.proofHandler.Handle)
+		}
+
 		// Token-specific endpoint: /:token (e.g., /usdt, /quotes)
 		v1.GET("/:token", r.getByTokenHandler.Handle)
+
+		// Live quotes for a token over Server-Sent Events
+		v1.GET("/:token/events", r.eventsHandler.Handle)
+
+		// Time- and volume-weighted average prices for oracle consumers
+		v1.GET("/:token/twap", r.averageHandler.HandleTWAP)
+		v1.GET("/:token/vwap24h", r.averageHandler.HandleVWAP24h)
+
+		// Range statistics and risk metrics
+		v1.GET("/:token/stats", r.statsHandler.Handle)
+
+		// One price per local calendar day for accounting
+		v1.GET("/:token/daily", r.dailyHandler.Handle)
 	}
 }
@@ -0,0 +1,209 @@
+package timeparam
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"quotes/internal/core/api/http/apierror"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultSpan is the length of a range when "from" is not given
+const DefaultSpan = 24 * time.Hour
+
+// Formats is the accepted syntax, used in error messages and API docs
+const Formats = "RFC3339 (2025-01-01T00:00:00Z), a date (2025-01-01), unix seconds or milliseconds, or a relative time (-24h, now-7d)"
+
+// unixMillisThreshold separates unix seconds from milliseconds: 1e11 seconds is in the year 5138
+const unixMillisThreshold = 100_000_000_000
+
+// dateLayouts are parsed in the location given by the tz parameter
+var dateLayouts = []string{
+	time.DateOnly,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+}
+
+// maxOffset is the largest relative offset, about 292 years
+const maxOffset = time.Duration(math.MaxInt64)
+
+var units = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// Range is a time range taken from the "from" and "to" query parameters
+type Range struct {
+	From time.Time
+	To   time.Time
+	// Explicit is false when neither parameter was given and the range is the default one
+	Explicit bool
+}
+
+// Parse parses a time parameter. Accepted values are RFC3339 timestamps, dates and
+// local date-times without an offset (interpreted in loc), unix seconds or milliseconds,
+// and times relative to now: "now", "now-7d", "now+1h", or just "-24h".
+// Relative offsets combine the units s, m, h, d and w (e.g., -1d12h).
+func Parse(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	return parse(value, now, loc, false)
+}
+
+// ParseEnd parses the end of a range like Parse, except that a date (2025-01-01) covers the
+// whole day: it ends at the last instant before the next midnight in loc.
+func ParseEnd(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	return parse(value, now, loc, true)
+}
+
+func parse(value string, now time.Time, loc *time.Location, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("empty time")
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			if end && layout == time.DateOnly {
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			return t.UTC(), nil
+		}
+	}
+
+	if isDigits(value) {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if n >= unixMillisThreshold {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+
+	relative := strings.TrimPrefix(strings.ToLower(value), "now")
+	if relative == "" {
+		return now.UTC(), nil
+	}
+	offset, err := parseOffset(relative)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(offset).UTC(), nil
+}
+
+// Location returns the location named by the "tz" query parameter (IANA name), UTC by default
+func Location(c *gin.Context) (*time.Location, error) {
+	name := c.Query("tz")
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, apierror.InvalidParameter(fmt.Sprintf("Invalid 'tz' parameter '%s'. Use an IANA time zone name (e.g., Europe/Paris)", name))
+	}
+	return loc, nil
+}
+
+// Time parses the named query parameter; ok is false when it is missing
+func Time(c *gin.Context, name string, now time.Time, loc *time.Location) (t time.Time, ok bool, err error) {
+	return query(c, name, now, loc, false)
+}
+
+func query(c *gin.Context, name string, now time.Time, loc *time.Location, end bool) (t time.Time, ok bool, err error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	t, err = parse(value, now, loc, end)
+	if err != nil {
+		return time.Time{}, false, apierror.InvalidParameter(fmt.Sprintf("Invalid '%s' parameter. Use %s", name, Formats))
+	}
+	return t, true, nil
+}
+
+// ParseRange reads "from", "to" and "tz" with the rules shared by every endpoint:
+// "to" defaults to now and "from" to DefaultSpan before "to"; a date given as "to" covers
+// the whole day. A range ending before it starts is an InvalidRange error.
+func ParseRange(c *gin.Context, now time.Time) (Range, error) {
+	loc, err := Location(c)
+	if err != nil {
+		return Range{}, err
+	}
+
+	to, hasTo, err := query(c, "to", now, loc, true)
+	if err != nil {
+		return Range{}, err
+	}
+	if !hasTo {
+		to = now.UTC()
+	}
+
+	from, hasFrom, err := Time(c, "from", now, loc)
+	if err != nil {
+		return Range{}, err
+	}
+	if !hasFrom {
+		from = to.Add(-DefaultSpan)
+	}
+
+	if from.After(to) {
+		return Range{}, quotes.InvalidRange("Invalid time range: 'from' must be before 'to'")
+	}
+
+	return Range{From: from, To: to, Explicit: hasFrom || hasTo}, nil
+}
+
+// parseOffset parses a signed offset such as -24h, +30m or -1d12h
+func parseOffset(value string) (time.Duration, error) {
+	if len(value) < 3 || (value[0] != '-' && value[0] != '+') {
+		return 0, fmt.Errorf("invalid relative time %q", value)
+	}
+
+	var offset time.Duration
+	rest := value[1:]
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return 0, fmt.Errorf("invalid relative time %q", value)
+		}
+		unit, ok := units[rest[i]]
+		if !ok {
+			return 0, fmt.Errorf("invalid unit %q in relative time %q", rest[i], value)
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil || n > int64(maxOffset-offset)/int64(unit) {
+			return 0, fmt.Errorf("relative time %q is out of range", value)
+		}
+		offset += time.Duration(n) * unit
+		rest = rest[i+1:]
+	}
+
+	if value[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
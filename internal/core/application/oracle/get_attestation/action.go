@@ -0,0 +1,113 @@
+package get_attestation
+
+import (
+	"context"
+	"fmt"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	GetLastQuote(ctx context.Context, tokenName string) (quotes.Quote, error)
+	GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error)
+}
+
+type Signer interface {
+	Attest(ctx context.Context, payload oracle.Payload) (oracle.Attestation, error)
+}
+
+type Action struct {
+	repo     Repository
+	signer   Signer
+	decimals int
+	maxGap   time.Duration
+
+	mu     sync.Mutex
+	signed map[attestationKey]oracle.Attestation // last attestation of each token and currency
+}
+
+type attestationKey struct {
+	token    string
+	currency quotes.Currency
+}
+
+// New creates the action; forward-filled prices are replaced by the last observed price within maxGap
+func New(repo Repository, signer Signer, decimals int, maxGap time.Duration) *Action {
+	return &Action{
+		repo:     repo,
+		signer:   signer,
+		decimals: decimals,
+		maxGap:   maxGap,
+		signed:   make(map[attestationKey]oracle.Attestation),
+	}
+}
+
+// Execute signs the latest observed price of a token in a currency. The payload carries the time the
+// price was observed, so a price repeated by forward-filling is never attested as newer than it is.
+// The signer is only reached when the observed price changes: until then the last attestation is served.
+func (a *Action) Execute(ctx context.Context, tokenName string, currency quotes.Currency) (oracle.Attestation, error) {
+	quote, err := a.repo.GetLastQuote(ctx, tokenName)
+	if err != nil {
+		return oracle.Attestation{}, err
+	}
+
+	if quote.IsFilled(currency) {
+		quote, err = a.lastObserved(ctx, tokenName, currency, quote.Timestamp)
+		if err != nil {
+			return oracle.Attestation{}, err
+		}
+	}
+
+	payload, err := oracle.NewPayload(tokenName, currency, quote, a.decimals)
+	if err != nil {
+		return oracle.Attestation{}, err
+	}
+
+	key := attestationKey{token: tokenName, currency: currency}
+	if attestation, ok := a.cached(key, payload); ok {
+		return attestation, nil
+	}
+
+	attestation, err := a.signer.Attest(ctx, payload)
+	if err != nil {
+		return oracle.Attestation{}, err
+	}
+
+	a.mu.Lock()
+	if current, ok := a.signed[key]; !ok || !current.Payload.Timestamp.After(payload.Timestamp) {
+		a.signed[key] = attestation
+	}
+	a.mu.Unlock()
+	return attestation, nil
+}
+
+// cached returns the last attestation of key if it signs the same payload
+func (a *Action) cached(key attestationKey, payload oracle.Payload) (oracle.Attestation, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	attestation, ok := a.signed[key]
+	if !ok || !attestation.Payload.Timestamp.Equal(payload.Timestamp) ||
+		attestation.Payload.Price.Cmp(payload.Price) != 0 || attestation.Payload.Decimals != payload.Decimals {
+		return oracle.Attestation{}, false
+	}
+	return attestation, true
+}
+
+func (a *Action) lastObserved(ctx context.Context, tokenName string, currency quotes.Currency, latest time.Time) (quotes.Quote, error) {
+	quotesList, err := a.repo.GetQuotes(ctx, latest.Add(-a.maxGap), latest, 0, tokenName)
+	if err != nil {
+		return quotes.Quote{}, err
+	}
+	for i := len(quotesList) - 1; i >= 0; i-- {
+		if !quotesList[i].IsFilled(currency) {
+			return quotesList[i], nil
+		}
+	}
+	return quotes.Quote{}, &quotes.Error{
+		Kind:    quotes.ErrNoData,
+		Message: fmt.Sprintf("no observed %s price for token '%s' within %s", currency, tokenName, a.maxGap),
+	}
+}
@@ -0,0 +1,118 @@
+package get_candles
+
+import (
+	"context"
+	"errors"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error)
+}
+
+type Signer interface {
+	SignCandle(ctx context.Context, candle oracle.Candle) (oracle.SignedCandle, error)
+}
+
+// Asset is a token price served as a Harbinger asset
+type Asset struct {
+	Token    string
+	Currency quotes.Currency
+}
+
+type Action struct {
+	repo     Repository
+	signer   Signer
+	assets   []Asset
+	interval time.Duration
+	maxGap   time.Duration
+
+	mu     sync.Mutex
+	signed map[string]oracle.SignedCandle // last signed candle of each asset
+}
+
+// New creates the action; candles span interval and are looked up to maxGap back when the latest is empty
+func New(repo Repository, signer Signer, assets []Asset, interval, maxGap time.Duration) *Action {
+	return &Action{
+		repo:     repo,
+		signer:   signer,
+		assets:   assets,
+		interval: interval,
+		maxGap:   maxGap,
+		signed:   make(map[string]oracle.SignedCandle),
+	}
+}
+
+// Execute signs the latest completed candle of every asset, in the order of the assets. Assets without
+// an observed price within maxGap are left out; the feed fails with NoData only when all of them are.
+// A candle is only signed once per asset and start, unless its prices change.
+func (a *Action) Execute(ctx context.Context, now time.Time) ([]oracle.SignedCandle, error) {
+	end := now.UTC().Truncate(a.interval)
+
+	var candles []oracle.SignedCandle
+	var lastErr error
+	for _, asset := range a.assets {
+		candle, err := a.latestCandle(ctx, asset, end)
+		if err != nil {
+			if errors.Is(err, quotes.ErrNoData) {
+				lastErr = err
+				continue
+			}
+			return nil, err
+		}
+
+		signed, err := a.sign(ctx, candle)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, signed)
+	}
+
+	if len(candles) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return candles, nil
+}
+
+// sign returns the cached signature of the candle, or has the signer sign it
+func (a *Action) sign(ctx context.Context, candle oracle.Candle) (oracle.SignedCandle, error) {
+	a.mu.Lock()
+	cached, ok := a.signed[candle.Asset]
+	a.mu.Unlock()
+	if ok && cached.Candle.Start.Equal(candle.Start) && cached.Candle.Michelson() == candle.Michelson() {
+		return cached, nil
+	}
+
+	signed, err := a.signer.SignCandle(ctx, candle)
+	if err != nil {
+		return oracle.SignedCandle{}, err
+	}
+
+	a.mu.Lock()
+	if current, ok := a.signed[candle.Asset]; !ok || !current.Candle.Start.After(candle.Start) {
+		a.signed[candle.Asset] = signed
+	}
+	a.mu.Unlock()
+	return signed, nil
+}
+
+// latestCandle returns the candle ending at end, or the last earlier one with an observed price
+func (a *Action) latestCandle(ctx context.Context, asset Asset, end time.Time) (oracle.Candle, error) {
+	quotesList, err := a.repo.GetQuotes(ctx, end.Add(-a.maxGap-a.interval), end, 0, asset.Token)
+	if err != nil {
+		return oracle.Candle{}, err
+	}
+
+	start := end.Add(-a.interval)
+	for i := len(quotesList) - 1; i >= 0; i-- {
+		quote := quotesList[i]
+		if quote.Timestamp.Before(end) && !quote.IsFilled(asset.Currency) && quote.Price(asset.Currency) > 0 {
+			start = quote.Timestamp.UTC().Truncate(a.interval)
+			break
+		}
+	}
+	return oracle.NewCandle(asset.Token, asset.Currency, start, start.Add(a.interval), quotesList)
+}
@@ -0,0 +1,83 @@
+package get_proof
+
+import (
+	"context"
+	"fmt"
+	"quotes/internal/core/domain/proofs"
+	"quotes/internal/core/domain/quotes"
+	"time"
+)
+
+type Repository interface {
+	GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error)
+}
+
+type Commitments interface {
+	Get(ctx context.Context, tokenName string, day time.Time) (proofs.Commitment, error)
+}
+
+// Proof shows that a quote is a leaf of the committed tree of its day
+type Proof struct {
+	Commitment proofs.Commitment
+	Quote      quotes.Quote
+	Leaf       []byte
+	Index      int
+	Steps      []proofs.ProofStep
+}
+
+type Action struct {
+	repo        Repository
+	commitments Commitments
+}
+
+func New(repo Repository, commitments Commitments) *Action {
+	return &Action{repo: repo, commitments: commitments}
+}
+
+// Execute proves the quote of a token at ts, or the last one before it on the same UTC day. The tree is
+// rebuilt from the stored quotes and must match the committed root: quotes rewritten after their day was
+// committed cannot be proven.
+func (a *Action) Execute(ctx context.Context, tokenName string, ts time.Time) (Proof, error) {
+	day := proofs.DayStart(ts)
+	commitment, err := a.commitments.Get(ctx, tokenName, day)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	quotesList, err := a.repo.GetQuotes(ctx, day, day.Add(proofs.Day), 0, tokenName)
+	if err != nil {
+		return Proof{}, err
+	}
+	dayQuotes := proofs.DayQuotes(day, quotesList)
+
+	tree := proofs.NewDayTree(tokenName, dayQuotes)
+	if tree.Root() != commitment.Root || len(dayQuotes) != commitment.LeafCount {
+		return Proof{}, &quotes.Error{
+			Kind: quotes.ErrCommitmentMismatch,
+			Message: fmt.Sprintf("stored quotes of token '%s' for %s no longer match the committed root %s",
+				tokenName, day.Format(time.DateOnly), commitment.Root),
+		}
+	}
+
+	index := -1
+	for i, quote := range dayQuotes {
+		if quote.Timestamp.After(ts) {
+			break
+		}
+		index = i
+	}
+	if index < 0 {
+		return Proof{}, &quotes.Error{
+			Kind:    quotes.ErrNoData,
+			Message: fmt.Sprintf("no quote for token '%s' on %s at or before %s", tokenName, day.Format(time.DateOnly), ts.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	return Proof{
+		Commitment: commitment,
+		Quote:      dayQuotes[index],
+		Leaf:       proofs.Leaf(tokenName, dayQuotes[index]),
+		Index:      index,
+		Steps:      tree.Proof(index),
+	}, nil
+}
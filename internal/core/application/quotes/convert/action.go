@@ -0,0 +1,64 @@
+package convert
+
+import (
+	"context"
+	"fmt"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"time"
+)
+
+type Repository interface {
+	GetLastQuote(ctx context.Context, tokenName string) (quotes.Quote, error)
+	GetQuotesAt(ctx context.Context, tokenName string, timestamps []time.Time) ([]quotes.Quote, error)
+}
+
+type Action struct {
+	repo   Repository
+	maxAge time.Duration
+}
+
+// New creates the action; at a given moment, quotes older than maxAge are not used
+func New(repo Repository, maxAge time.Duration) *Action {
+	return &Action{repo: repo, maxAge: maxAge}
+}
+
+// Execute converts amount between a token and a currency or between two tokens, with the
+// latest quotes when at is zero and otherwise with the last quotes at or before at.
+func (a *Action) Execute(ctx context.Context, amount float64, from, to string, at time.Time) (quotes.Conversion, error) {
+	quotesByToken := make(map[string]quotes.Quote)
+	for _, symbol := range []string{strings.ToLower(from), strings.ToLower(to)} {
+		if !quotes.IsTokenSupported(symbol) {
+			continue
+		}
+		if _, ok := quotesByToken[symbol]; ok {
+			continue
+		}
+
+		quote, err := a.quote(ctx, symbol, at)
+		if err != nil {
+			return quotes.Conversion{}, err
+		}
+		quotesByToken[symbol] = quote
+	}
+
+	return quotes.Convert(amount, from, to, quotesByToken)
+}
+
+func (a *Action) quote(ctx context.Context, tokenName string, at time.Time) (quotes.Quote, error) {
+	if at.IsZero() {
+		return a.repo.GetLastQuote(ctx, tokenName)
+	}
+
+	quotesAt, err := a.repo.GetQuotesAt(ctx, tokenName, []time.Time{at})
+	if err != nil {
+		return quotes.Quote{}, err
+	}
+	if len(quotesAt) == 0 || quotesAt[0].Timestamp.IsZero() || at.Sub(quotesAt[0].Timestamp) > a.maxAge {
+		return quotes.Quote{}, &quotes.Error{
+			Kind:    quotes.ErrNoData,
+			Message: fmt.Sprintf("no quote for token '%s' within %s before %s", tokenName, a.maxAge, at.UTC().Format(time.RFC3339)),
+		}
+	}
+	return quotesAt[0], nil
+}
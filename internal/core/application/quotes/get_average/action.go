@@ -0,0 +1,43 @@
+package get_average
+
+import (
+	"context"
+	"fmt"
+	"quotes/internal/core/domain/quotes"
+	"time"
+)
+
+type Repository interface {
+	GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error)
+}
+
+type Action struct {
+	repo   Repository
+	maxGap time.Duration
+}
+
+// New creates the action; prices older than maxGap are no longer considered fresh
+func New(repo Repository, maxGap time.Duration) *Action {
+	return &Action{repo: repo, maxGap: maxGap}
+}
+
+// Execute averages the price of a token over the window ending at "at".
+// Returns a quotes.ErrNoData error when no quote in the window can be averaged.
+func (a *Action) Execute(ctx context.Context, tokenName string, method quotes.AverageMethod, currency quotes.Currency, at time.Time, window time.Duration) (quotes.Average, error) {
+	from := at.Add(-window)
+
+	// Quotes up to maxGap before the window may still be fresh at its start
+	quotesList, err := a.repo.GetQuotes(ctx, from.Add(-a.maxGap), at, 0, tokenName)
+	if err != nil {
+		return quotes.Average{}, err
+	}
+
+	average := quotes.ComputeAverage(method, currency, quotesList, from, at, a.maxGap)
+	if average.Points == 0 {
+		return quotes.Average{}, &quotes.Error{
+			Kind:    quotes.ErrNoData,
+			Message: fmt.Sprintf("no %s quotes for token '%s' to compute the %s over the window", currency, tokenName, method),
+		}
+	}
+	return average, nil
+}
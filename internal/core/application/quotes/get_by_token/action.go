@@ -3,7 +3,6 @@ package get_by_token
 import (
 	"context"
 	"quotes/internal/core/domain/quotes"
-	"strings"
 	"time"
 )
 
@@ -19,15 +18,7 @@ func New(repo Repository) *Action {
 	return &Action{repo: repo}
 }
 
+// Execute returns quotes of a token; errors are domain errors (quotes.ErrTokenNotFound, quotes.ErrInvalidRange, ...)
 func (a *Action) Execute(ctx context.Context, tokenName string, from, to time.Time, limit int) ([]quotes.Quote, error) {
-	quotes, err := a.repo.GetQuotes(ctx, from, to, limit, tokenName)
-	if err != nil {
-		// Check if error is about unsupported token
-		if strings.Contains(err.Error(), "not supported") {
-			return nil, ErrTokenNotFound
-		}
-		return nil, err
-	}
-
-	return quotes, nil
+	return a.repo.GetQuotes(ctx, from, to, limit, tokenName)
 }
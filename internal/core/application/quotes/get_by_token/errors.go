@@ -1,7 +0,0 @@
-package get_by_token
-
-import "errors"
-
-var (
-	ErrTokenNotFound = errors.New("token not found")
-)
@@ -0,0 +1,23 @@
+package get_daily
+
+import (
+	"context"
+	"quotes/internal/core/domain/quotes"
+	"time"
+)
+
+type Repository interface {
+	GetDaily(ctx context.Context, tokenName string, currency quotes.Currency, loc *time.Location, from, to time.Time) ([]quotes.DailyPrice, error)
+}
+
+type Action struct {
+	repo Repository
+}
+
+func New(repo Repository) *Action {
+	return &Action{repo: repo}
+}
+
+func (a *Action) Execute(ctx context.Context, tokenName string, currency quotes.Currency, loc *time.Location, from, to time.Time) ([]quotes.DailyPrice, error) {
+	return a.repo.GetDaily(ctx, tokenName, currency, loc, from, to)
+}
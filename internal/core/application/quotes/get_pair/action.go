@@ -0,0 +1,23 @@
+package get_pair
+
+import (
+	"context"
+	"quotes/internal/core/domain/quotes"
+	"time"
+)
+
+type Repository interface {
+	GetPairQuotes(ctx context.Context, baseToken, quoteToken string, pivot quotes.Currency, tolerance time.Duration, from, to time.Time, limit int) ([]quotes.PairQuote, error)
+}
+
+type Action struct {
+	repo Repository
+}
+
+func New(repo Repository) *Action {
+	return &Action{repo: repo}
+}
+
+func (a *Action) Execute(ctx context.Context, baseToken, quoteToken string, pivot quotes.Currency, tolerance time.Duration, from, to time.Time, limit int) ([]quotes.PairQuote, error) {
+	return a.repo.GetPairQuotes(ctx, baseToken, quoteToken, pivot, tolerance, from, to, limit)
+}
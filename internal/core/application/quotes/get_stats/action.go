@@ -0,0 +1,23 @@
+package get_stats
+
+import (
+	"context"
+	"quotes/internal/core/domain/quotes"
+	"time"
+)
+
+type Repository interface {
+	GetStats(ctx context.Context, tokenName string, currency quotes.Currency, from, to time.Time) (quotes.Stats, error)
+}
+
+type Action struct {
+	repo Repository
+}
+
+func New(repo Repository) *Action {
+	return &Action{repo: repo}
+}
+
+func (a *Action) Execute(ctx context.Context, tokenName string, currency quotes.Currency, from, to time.Time) (quotes.Stats, error) {
+	return a.repo.GetStats(ctx, tokenName, currency, from, to)
+}
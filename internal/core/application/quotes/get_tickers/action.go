@@ -0,0 +1,35 @@
+package get_tickers
+
+import (
+	"context"
+	"errors"
+	"quotes/internal/core/domain/quotes"
+)
+
+type Repository interface {
+	GetTicker(ctx context.Context, tokenName string) (quotes.Ticker, error)
+}
+
+type Action struct {
+	repo Repository
+}
+
+func New(repo Repository) *Action {
+	return &Action{repo: repo}
+}
+
+// Execute returns the tickers of the given tokens in order, leaving out tokens without quotes yet
+func (a *Action) Execute(ctx context.Context, tokenNames []string) ([]quotes.Ticker, error) {
+	tickers := make([]quotes.Ticker, 0, len(tokenNames))
+	for _, tokenName := range tokenNames {
+		ticker, err := a.repo.GetTicker(ctx, tokenName)
+		if errors.Is(err, quotes.ErrNoData) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
+}
@@ -0,0 +1,36 @@
+package valuate
+
+import (
+	"context"
+	"quotes/internal/core/domain/quotes"
+	"time"
+)
+
+type Repository interface {
+	GetQuotesAt(ctx context.Context, tokenName string, timestamps []time.Time) ([]quotes.Quote, error)
+}
+
+type Action struct {
+	repo   Repository
+	maxAge time.Duration
+}
+
+// New creates the action; quotes older than maxAge at an entry timestamp are flagged as stale
+func New(repo Repository, maxAge time.Duration) *Action {
+	return &Action{repo: repo, maxAge: maxAge}
+}
+
+// Execute values amounts of a token in currencies with point-in-time quotes
+func (a *Action) Execute(ctx context.Context, tokenName string, entries []quotes.ValuationEntry, currencies []quotes.Currency) (quotes.ValuationReport, error) {
+	timestamps := make([]time.Time, len(entries))
+	for i, entry := range entries {
+		timestamps[i] = entry.Timestamp
+	}
+
+	quotesAt, err := a.repo.GetQuotesAt(ctx, tokenName, timestamps)
+	if err != nil {
+		return quotes.ValuationReport{}, err
+	}
+
+	return quotes.Valuate(entries, quotesAt, currencies, a.maxAge), nil
+}
@@ -0,0 +1,30 @@
+package apikeys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// Tier is a named rate-limit tier an API key belongs to
+type Tier string
+
+const (
+	TierAnonymous Tier = "anonymous"
+	TierPartner   Tier = "partner"
+	TierInternal  Tier = "internal"
+)
+
+var ErrKeyNotFound = errors.New("api key not found")
+
+// APIKey identifies a client calling the API
+type APIKey struct {
+	Name string
+	Tier Tier
+}
+
+// HashKey returns the hex-encoded SHA-256 of a raw key; only hashes are stored in the database
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package oracle
+
+import (
+	"fmt"
+	"math/big"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MichelsonType is the type of the packed payload: timestamp, token, currency and price
+const MichelsonType = "pair timestamp string string nat"
+
+// Payload is the price statement signed for on-chain consumers
+type Payload struct {
+	Timestamp time.Time
+	Token     string
+	Currency  quotes.Currency
+	Price     *big.Int // nat: price x 10^Decimals, rounded
+	Decimals  int
+}
+
+// NewPayload builds the payload of a quote price in the given currency
+func NewPayload(token string, currency quotes.Currency, quote quotes.Quote, decimals int) (Payload, error) {
+	price := quote.Price(currency)
+	if price <= 0 {
+		return Payload{}, &quotes.Error{Kind: quotes.ErrNoData, Message: fmt.Sprintf("no %s price for token '%s'", currency, token)}
+	}
+
+	nat := toNat(price, decimals)
+	if nat.Sign() == 0 {
+		return Payload{}, &quotes.Error{Kind: quotes.ErrNoData, Message: fmt.Sprintf("%s price of token '%s' rounds to 0 with %d decimals", currency, token, decimals)}
+	}
+
+	return Payload{
+		Timestamp: quote.Timestamp.UTC().Truncate(time.Second),
+		Token:     token,
+		Currency:  currency,
+		Price:     nat,
+		Decimals:  decimals,
+	}, nil
+}
+
+// toNat returns value x 10^decimals, rounded, formatting the exact decimal expansion so large values
+// do not lose precision in a float multiplication
+func toNat(value float64, decimals int) *big.Int {
+	digits := strings.Replace(strconv.FormatFloat(value, 'f', decimals, 64), ".", "", 1)
+	nat, ok := new(big.Int).SetString(digits, 10)
+	if !ok || nat.Sign() < 0 {
+		return new(big.Int)
+	}
+	return nat
+}
+
+// DecimalPrice returns the signed price as a decimal number
+func (p Payload) DecimalPrice() float64 {
+	price, _ := new(big.Rat).SetFrac(p.Price, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Decimals)), nil)).Float64()
+	return price
+}
+
+// Michelson returns the payload as a Michelson value of MichelsonType
+func (p Payload) Michelson() string {
+	return fmt.Sprintf("Pair %d %q %q %s", p.Timestamp.Unix(), p.Token, string(p.Currency), p.Price.String())
+}
+
+// Key identifies the key attestations are signed with
+type Key struct {
+	Algorithm     string `json:"algorithm" example:"ed25519"`                                                 // ed25519, secp256k1 or p256
+	PublicKey     string `json:"public_key" example:"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"` // edpk..., sppk... or p2pk...
+	PublicKeyHash string `json:"public_key_hash" example:"mv18Cw7psUrAAPBpXYd9CtCpHg9EgjHP9KTe"`              // mv1..., mv2... or mv3...
+}
+
+// Attestation is a signed payload: Signature signs the Blake2b-256 digest of Packed, as CHECK_SIGNATURE expects
+type Attestation struct {
+	Payload   Payload
+	Packed    []byte // PACK of the payload (0x05 followed by its binary Micheline encoding)
+	Signature string // edsig..., spsig1... or p2sig...
+	Key       Key
+}
@@ -0,0 +1,103 @@
+package oracle
+
+import (
+	"fmt"
+	"math/big"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"time"
+)
+
+// Harbinger oracle message format: Pair asset (Pair start (Pair end (Pair open (Pair high (Pair low (Pair close volume))))))
+const (
+	CandleMichelsonType = "pair string (pair timestamp (pair timestamp (pair nat (pair nat (pair nat (pair nat nat))))))"
+	// CandleDecimals is the fixed precision of Harbinger prices and volumes
+	CandleDecimals = 6
+	// CandleVolumeMethod tells feed consumers how NewCandle derives volumes: quotes carry no per-interval volume
+	CandleVolumeMethod = "synthetic: rolling 24h USD volume of the candle quotes prorated to the candle length, " +
+		"in token units at the candle USD price, at least 1 (0.000001 token)"
+)
+
+// Candle is an OHLCV candle of observed prices, as Harbinger oracle contracts store them
+type Candle struct {
+	Asset  string // e.g. MVRK-USD
+	Start  time.Time
+	End    time.Time
+	Open   *big.Int // nat: price x 10^CandleDecimals
+	High   *big.Int
+	Low    *big.Int
+	Close  *big.Int
+	Volume *big.Int // nat: token units traded x 10^CandleDecimals, never 0
+}
+
+// SignedCandle is a candle with its packed message and signature, as a Harbinger signer serves them
+type SignedCandle struct {
+	Candle    Candle
+	Packed    []byte // PACK of the candle (0x05 followed by its binary Micheline encoding)
+	Signature string
+}
+
+// CandleAsset returns the Harbinger asset name of a token price, e.g. MVRK-USD
+func CandleAsset(token string, currency quotes.Currency) string {
+	return strings.ToUpper(token) + "-" + strings.ToUpper(string(currency))
+}
+
+// NewCandle builds the candle of the prices observed in [start, end). Forward-filled prices are ignored.
+// The volume is the share of the reported 24h USD volume falling in the candle, in token units; it is
+// at least one unit of the last decimal because normalizer contracts weight prices by volume.
+func NewCandle(token string, currency quotes.Currency, start, end time.Time, quotesList []quotes.Quote) (Candle, error) {
+	var open, high, low, closePrice, volumeUSD, priceUSD float64
+	observed := 0
+	for _, quote := range quotesList {
+		price := quote.Price(currency)
+		if quote.Timestamp.Before(start) || !quote.Timestamp.Before(end) || quote.IsFilled(currency) || price <= 0 {
+			continue
+		}
+		if observed == 0 {
+			open, high, low = price, price, price
+		}
+		high = max(high, price)
+		low = min(low, price)
+		closePrice = price
+		if quote.Volume24h > 0 && quote.USD > 0 {
+			volumeUSD += quote.Volume24h
+			priceUSD += quote.USD
+		}
+		observed++
+	}
+	if observed == 0 {
+		return Candle{}, &quotes.Error{
+			Kind:    quotes.ErrNoData,
+			Message: fmt.Sprintf("no observed %s price for token '%s' between %s and %s", currency, token, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	volume := new(big.Int)
+	if priceUSD > 0 {
+		volume = toNat(volumeUSD/priceUSD*end.Sub(start).Hours()/24, CandleDecimals)
+	}
+	if volume.Sign() == 0 {
+		volume.SetInt64(1)
+	}
+
+	candle := Candle{
+		Asset:  CandleAsset(token, currency),
+		Start:  start.UTC(),
+		End:    end.UTC(),
+		Open:   toNat(open, CandleDecimals),
+		High:   toNat(high, CandleDecimals),
+		Low:    toNat(low, CandleDecimals),
+		Close:  toNat(closePrice, CandleDecimals),
+		Volume: volume,
+	}
+	if candle.Low.Sign() == 0 {
+		return Candle{}, &quotes.Error{Kind: quotes.ErrNoData, Message: fmt.Sprintf("%s price of token '%s' rounds to 0 with %d decimals", currency, token, CandleDecimals)}
+	}
+	return candle, nil
+}
+
+// Michelson returns the candle as a Michelson value of CandleMichelsonType
+func (c Candle) Michelson() string {
+	return fmt.Sprintf("Pair %q (Pair %d (Pair %d (Pair %s (Pair %s (Pair %s (Pair %s %s))))))",
+		c.Asset, c.Start.Unix(), c.End.Unix(), c.Open, c.High, c.Low, c.Close, c.Volume)
+}
@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"math"
+	"time"
+)
+
+// PushReason tells why an update was submitted to the oracle contract
+type PushReason string
+
+const (
+	PushInitial   PushReason = "initial"   // nothing pushed yet
+	PushDeviation PushReason = "deviation" // price moved more than the deviation threshold
+	PushHeartbeat PushReason = "heartbeat" // no update for the heartbeat interval
+)
+
+// PushStatus tells whether the operation carrying an update made it into a block
+type PushStatus string
+
+const (
+	PushPending  PushStatus = "pending"  // injected, not seen in a block yet
+	PushIncluded PushStatus = "included" // found in a block
+	PushFailed   PushStatus = "failed"   // not included before the operation expired
+)
+
+// Push is an update submitted to an oracle contract
+type Push struct {
+	Contract      string
+	Payload       Payload
+	Reason        PushReason
+	OperationHash string
+	PushedAt      time.Time
+	Status        PushStatus
+	InjectedLevel int64 // head level when the operation was injected
+	IncludedLevel int64 // level of the block including the operation (0 until included)
+}
+
+// PushPolicy decides when a new price is worth submitting
+type PushPolicy struct {
+	DeviationPercent float64       // push when the price moved at least this much from the last pushed price
+	Heartbeat        time.Duration // push at least this often, even when the price is flat
+}
+
+// Decide returns why the payload should be pushed given the last push that did not fail (nil when none),
+// or false. The pusher does not call it while that push is still pending.
+// A payload is never pushed unless it was observed after the last pushed one.
+func (p PushPolicy) Decide(last *Push, payload Payload, now time.Time) (PushReason, bool) {
+	if last == nil {
+		return PushInitial, true
+	}
+	if !payload.Timestamp.After(last.Payload.Timestamp) {
+		return "", false
+	}
+
+	if previous := last.Payload.DecimalPrice(); previous > 0 {
+		deviation := math.Abs(payload.DecimalPrice()-previous) / previous * 100
+		if deviation >= p.DeviationPercent {
+			return PushDeviation, true
+		}
+	}
+	if now.Sub(last.PushedAt) >= p.Heartbeat {
+		return PushHeartbeat, true
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package proofs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"quotes/internal/core/domain/quotes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day is the period a commitment covers
+const Day = 24 * time.Hour
+
+// leafCurrencies are the prices of a leaf, in order. The list is part of the leaf format and must not change.
+var leafCurrencies = []quotes.Currency{
+	quotes.CurrencyBTC, quotes.CurrencyUSD, quotes.CurrencyEUR, quotes.CurrencyCNY,
+	quotes.CurrencyJPY, quotes.CurrencyKRW, quotes.CurrencyETH, quotes.CurrencyGBP,
+}
+
+// LeafFormat describes Leaf for API docs
+const LeafFormat = "token,unix_seconds,btc,usd,eur,cny,jpy,krw,eth,gbp (prices with 8 decimals)"
+
+// Commitment is the Merkle root of the quotes a token stored during a UTC day
+type Commitment struct {
+	Token       string
+	Day         time.Time // midnight UTC
+	Root        Hash
+	LeafCount   int
+	CommittedAt time.Time
+}
+
+// Leaf encodes a quote as a leaf of its day tree: the token, the unix timestamp and the prices
+// in leafCurrencies order with 8 decimals, comma-separated (e.g. "mvrk,1735689600,0.00000123,0.12345678,...")
+func Leaf(token string, quote quotes.Quote) []byte {
+	fields := make([]string, 0, 2+len(leafCurrencies))
+	fields = append(fields, strings.ToLower(token), strconv.FormatInt(quote.Timestamp.Unix(), 10))
+	for _, currency := range leafCurrencies {
+		fields = append(fields, strconv.FormatFloat(quote.Price(currency), 'f', 8, 64))
+	}
+	return []byte(strings.Join(fields, ","))
+}
+
+// DayStart returns the UTC day containing t
+func DayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(Day)
+}
+
+// DayQuotes keeps the quotes of the day starting at day, in timestamp order as stored
+func DayQuotes(day time.Time, quotesList []quotes.Quote) []quotes.Quote {
+	end := day.Add(Day)
+	var kept []quotes.Quote
+	for _, quote := range quotesList {
+		if !quote.Timestamp.Before(day) && quote.Timestamp.Before(end) {
+			kept = append(kept, quote)
+		}
+	}
+	return kept
+}
+
+// NewDayTree builds the tree of the quotes of a day, as returned by DayQuotes
+func NewDayTree(token string, dayQuotes []quotes.Quote) *Tree {
+	leaves := make([][]byte, len(dayQuotes))
+	for i, quote := range dayQuotes {
+		leaves[i] = Leaf(token, quote)
+	}
+	return NewTree(leaves)
+}
+
+// String returns the hash as lower-case hex
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// ParseHash parses a hex hash
+func ParseHash(value string) (Hash, error) {
+	var hash Hash
+	raw, err := hex.DecodeString(value)
+	if err != nil || len(raw) != len(hash) {
+		return hash, fmt.Errorf("invalid hash %q", value)
+	}
+	copy(hash[:], raw)
+	return hash, nil
+}
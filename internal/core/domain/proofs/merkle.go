@@ -0,0 +1,97 @@
+package proofs
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// Domain separation prefixes (as in RFC 6962), so a leaf can never be passed off as an inner node
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// Hash is a SHA-256 digest
+type Hash [sha256.Size]byte
+
+// ProofStep is a sibling on the path from a leaf to the root
+type ProofStep struct {
+	Hash Hash
+	Left bool // the sibling is hashed on the left of the current node
+}
+
+// Tree is a Merkle tree over ordered leaves. Leaves are hashed as SHA-256(0x00 || leaf) and inner nodes
+// as SHA-256(0x01 || left || right); the last node of a level with an odd count moves up unchanged.
+// This is the Merkle Tree Hash of RFC 6962; the root of an empty tree is SHA-256 of no data.
+type Tree struct {
+	levels [][]Hash // levels[0] are the leaf hashes, the last level is the root
+}
+
+func NewTree(leaves [][]byte) *Tree {
+	level := make([]Hash, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = LeafHash(leaf)
+	}
+
+	tree := &Tree{levels: [][]Hash{level}}
+	for len(level) > 1 {
+		next := make([]Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+			} else {
+				next = append(next, nodeHash(level[i], level[i+1]))
+			}
+		}
+		tree.levels = append(tree.levels, next)
+		level = next
+	}
+	return tree
+}
+
+// Root returns the root hash
+func (t *Tree) Root() Hash {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		return sha256.Sum256(nil)
+	}
+	return top[0]
+}
+
+// Proof returns the siblings from the leaf at index up to the root
+func (t *Tree) Proof(index int) []ProofStep {
+	var proof []ProofStep
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, ProofStep{Hash: level[sibling], Left: sibling < index})
+		}
+		index /= 2
+	}
+	return proof
+}
+
+// Verify checks that leaf is included under root
+func Verify(leaf []byte, proof []ProofStep, root Hash) bool {
+	hash := LeafHash(leaf)
+	for _, step := range proof {
+		if step.Left {
+			hash = nodeHash(step.Hash, hash)
+		} else {
+			hash = nodeHash(hash, step.Hash)
+		}
+	}
+	return bytes.Equal(hash[:], root[:])
+}
+
+func LeafHash(leaf []byte) Hash {
+	return sha256.Sum256(append([]byte{leafPrefix}, leaf...))
+}
+
+func nodeHash(left, right Hash) Hash {
+	data := make([]byte, 0, 1+2*sha256.Size)
+	data = append(data, nodePrefix)
+	data = append(data, left[:]...)
+	data = append(data, right[:]...)
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,130 @@
+package proofs
+
+import (
+	"encoding/hex"
+	"quotes/internal/core/domain/quotes"
+	"testing"
+	"time"
+)
+
+// Leaves and roots of the RFC 6962 test vectors (certificate-transparency merkle_tree_test)
+var rfc6962Leaves = []string{"", "00", "10", "2021", "3031", "40414243", "5051525354555657", "606162636465666768696a6b6c6d6e6f"}
+
+var rfc6962Roots = []string{
+	"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+	"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+	"aeb6bcfe274b70a14fb067a5e5578264db0fa9b51af5e0ba159158f329e06e77",
+	"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	"4e3bbb1f7b478dcfe71fb631631519a3bca12c9aefca1612bfce4c13a86264d4",
+	"76e67dadbcdf1e10e1b74ddc608abd2f98dfb16fbce75277b5232a127f2087ef",
+	"ddb89be403809e325750d3d263cd78929c2942b7942a34b77e122c9594a74c8c",
+	"5dc9da79a70659a9ad559cb701ded9a2ab9d823aad2f4960cfe370eff4604328",
+}
+
+func testLeaves(t *testing.T, n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaf, err := hex.DecodeString(rfc6962Leaves[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaves[i] = leaf
+	}
+	return leaves
+}
+
+func TestRoot(t *testing.T) {
+	if root := NewTree(nil).Root().String(); root != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("empty root = %s", root)
+	}
+	for n := 1; n <= len(rfc6962Roots); n++ {
+		if root := NewTree(testLeaves(t, n)).Root().String(); root != rfc6962Roots[n-1] {
+			t.Errorf("root of %d leaves = %s, want %s", n, root, rfc6962Roots[n-1])
+		}
+	}
+}
+
+func TestProofVerify(t *testing.T) {
+	for n := 1; n <= len(rfc6962Roots); n++ {
+		leaves := testLeaves(t, n)
+		tree := NewTree(leaves)
+		root := tree.Root()
+
+		for i, leaf := range leaves {
+			proof := tree.Proof(i)
+			if !Verify(leaf, proof, root) {
+				t.Errorf("leaf %d of %d: proof does not verify", i, n)
+			}
+			if Verify(append(leaf, 0x00), proof, root) {
+				t.Errorf("leaf %d of %d: proof verifies another leaf", i, n)
+			}
+			if other := (i + 1) % n; other != i && Verify(leaves[other], proof, root) {
+				t.Errorf("leaf %d of %d: proof verifies leaf %d", i, n, other)
+			}
+		}
+	}
+}
+
+func TestVerifyRejectsInnerNodeAsLeaf(t *testing.T) {
+	// Without domain separation, the two leaf hashes concatenated would hash to the root of a two-leaf tree
+	tree := NewTree(testLeaves(t, 2))
+	left, right := tree.levels[0][0], tree.levels[0][1]
+	if Verify(append(left[:], right[:]...), nil, tree.Root()) {
+		t.Error("an inner node was accepted as a leaf")
+	}
+}
+
+func TestLeaf(t *testing.T) {
+	quote := quotes.Quote{
+		Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		BTC:       0.00000123,
+		USD:       0.12345678,
+		EUR:       0.11,
+		CNY:       0.9,
+		JPY:       19.5,
+		KRW:       175,
+		ETH:       0.00004,
+		GBP:       0.1,
+		Volume24h: 1000, // not part of the leaf
+	}
+
+	want := "mvrk,1735689600,0.00000123,0.12345678,0.11000000,0.90000000,19.50000000,175.00000000,0.00004000,0.10000000"
+	leaf := Leaf("MVRK", quote)
+	if string(leaf) != want {
+		t.Errorf("Leaf() = %s, want %s", leaf, want)
+	}
+	if hash := LeafHash(leaf).String(); hash != "b4a39d570b833237ce547ce45a87465b0db5b3b6adc55b6ed9158586dace8c5d" {
+		t.Errorf("LeafHash() = %s", hash)
+	}
+}
+
+func TestDayQuotes(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	quotesList := []quotes.Quote{
+		{Timestamp: day.Add(-time.Second)},
+		{Timestamp: day},
+		{Timestamp: day.Add(Day - time.Second)},
+		{Timestamp: day.Add(Day)},
+	}
+
+	kept := DayQuotes(day, quotesList)
+	if len(kept) != 2 || !kept[0].Timestamp.Equal(day) || !kept[1].Timestamp.Equal(day.Add(Day-time.Second)) {
+		t.Errorf("DayQuotes() = %v", kept)
+	}
+	if start := DayStart(day.Add(Day - time.Second).In(time.FixedZone("UTC+2", 2*3600))); !start.Equal(day) {
+		t.Errorf("DayStart() = %s", start)
+	}
+}
+
+func TestParseHash(t *testing.T) {
+	root := NewTree(testLeaves(t, 3)).Root()
+	parsed, err := ParseHash(root.String())
+	if err != nil || parsed != root {
+		t.Errorf("ParseHash(%s) = %s, %v", root, parsed, err)
+	}
+	for _, value := range []string{"", "zz", root.String()[:62]} {
+		if _, err := ParseHash(value); err == nil {
+			t.Errorf("ParseHash(%q) accepted", value)
+		}
+	}
+}
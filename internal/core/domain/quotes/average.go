@@ -0,0 +1,123 @@
+package quotes
+
+import "time"
+
+// AverageMethod is the way quotes are averaged over a window
+type AverageMethod string
+
+const (
+	// MethodTWAP weights every observed price by how long it was the latest one
+	MethodTWAP AverageMethod = "twap"
+	// MethodVWAP24h weights every observed price by the rolling 24h trading volume reported with it.
+	// Volumes traded within the window are not known, so this is not a VWAP of the window's trades.
+	MethodVWAP24h AverageMethod = "vwap24h"
+)
+
+// Average is a price averaged over [From, To]
+type Average struct {
+	Method   AverageMethod
+	Currency Currency
+	Value    float64
+	From     time.Time
+	To       time.Time
+
+	// Points is the number of quotes that contributed to the value
+	Points int
+	// FilledPoints is the number of quotes in the window whose price was forward-filled;
+	// they are not observations and never contribute
+	FilledPoints int
+	// EffectiveFrom and EffectiveTo bound the part of the window covered by contributing quotes
+	EffectiveFrom time.Time
+	EffectiveTo   time.Time
+	// Covered is the time within the window during which a contributing price was fresh,
+	// i.e. at most maxGap old; the rest of the window is a gap
+	Covered time.Duration
+}
+
+// ComputeAverage averages the price of quotesList (sorted by timestamp) over [from, to].
+//
+// A price stays fresh until the next observation or for maxGap, whichever comes first, so a
+// stale price is never carried across a gap. The last observation before the window counts
+// for the beginning of the window. Forward-filled prices and prices missing in the currency
+// are ignored; VWAP24h also ignores quotes without a volume.
+//
+// Points is 0 when nothing in the window can be averaged.
+func ComputeAverage(method AverageMethod, currency Currency, quotesList []Quote, from, to time.Time, maxGap time.Duration) Average {
+	average := Average{Method: method, Currency: currency, From: from, To: to}
+
+	var observations []Quote
+	for _, quote := range quotesList {
+		if quote.Timestamp.After(to) {
+			break
+		}
+		if quote.IsFilled(currency) {
+			if !quote.Timestamp.Before(from) {
+				average.FilledPoints++
+			}
+			continue
+		}
+		if quote.Price(currency) <= 0 {
+			continue
+		}
+		if quote.Timestamp.Before(from) {
+			// Only the latest observation before the window can still be fresh at its start
+			if method != MethodTWAP {
+				continue
+			}
+			if len(observations) > 0 && observations[0].Timestamp.Before(from) {
+				observations = observations[:0]
+			}
+		}
+		if method == MethodVWAP24h && quote.Volume24h <= 0 {
+			continue
+		}
+		observations = append(observations, quote)
+	}
+
+	var weighted, weights float64
+	for i, quote := range observations {
+		start := quote.Timestamp
+		end := quote.Timestamp.Add(maxGap)
+		if i+1 < len(observations) && observations[i+1].Timestamp.Before(end) {
+			end = observations[i+1].Timestamp
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+
+		fresh := end.Sub(start)
+		if fresh > 0 {
+			average.Covered += fresh
+		}
+
+		switch method {
+		case MethodTWAP:
+			if fresh <= 0 {
+				continue
+			}
+			weighted += quote.Price(currency) * fresh.Seconds()
+			weights += fresh.Seconds()
+		case MethodVWAP24h:
+			weighted += quote.Price(currency) * quote.Volume24h
+			weights += quote.Volume24h
+		}
+
+		if average.Points == 0 {
+			average.EffectiveFrom = start
+		}
+		average.Points++
+		if end.After(average.EffectiveTo) {
+			average.EffectiveTo = end
+		}
+	}
+
+	if weights > 0 {
+		average.Value = weighted / weights
+	} else {
+		average.Points = 0
+	}
+	return average
+}
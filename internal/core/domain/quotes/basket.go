@@ -0,0 +1,188 @@
+package quotes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// basketNamePattern restricts basket names to valid table names
+var basketNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// BasketConstituent is a token held by a basket with its target weight
+type BasketConstituent struct {
+	Token  string
+	Weight float64 // normalized: the weights of a basket sum to 1
+}
+
+// Basket is a composite token priced from the quotes of its constituents.
+// At its first point and at every rebalance date each constituent is given units worth its weight
+// of the basket USD value; between rebalances the units are held and the price follows the constituents.
+type Basket struct {
+	Name           string
+	BaseValue      float64 // USD value at the first computed point
+	Constituents   []BasketConstituent
+	RebalanceDates []time.Time // ascending
+}
+
+// NewBasket validates a basket definition, normalizes its weights and sorts its rebalance dates
+func NewBasket(name string, baseValue float64, weights map[string]float64, rebalanceDates []time.Time) (Basket, error) {
+	if !basketNamePattern.MatchString(name) {
+		return Basket{}, fmt.Errorf("invalid basket name %q: use lower-case letters, digits and underscores", name)
+	}
+	if IsTokenSupported(name) {
+		return Basket{}, fmt.Errorf("basket %q conflicts with an existing token", name)
+	}
+	if baseValue <= 0 {
+		return Basket{}, fmt.Errorf("basket %q: base value must be positive", name)
+	}
+	if len(weights) == 0 {
+		return Basket{}, fmt.Errorf("basket %q has no constituents", name)
+	}
+
+	basket := Basket{Name: name, BaseValue: baseValue}
+	var total float64
+	for token, weight := range weights {
+		if !IsTokenSupported(token) || IsBasket(token) {
+			return Basket{}, fmt.Errorf("basket %q: unsupported constituent %q", name, token)
+		}
+		if weight <= 0 {
+			return Basket{}, fmt.Errorf("basket %q: weight of %q must be positive", name, token)
+		}
+		basket.Constituents = append(basket.Constituents, BasketConstituent{Token: strings.ToLower(token), Weight: weight})
+		total += weight
+	}
+	for i := range basket.Constituents {
+		basket.Constituents[i].Weight /= total
+	}
+	sort.Slice(basket.Constituents, func(i, j int) bool {
+		return basket.Constituents[i].Token < basket.Constituents[j].Token
+	})
+
+	basket.RebalanceDates = append([]time.Time(nil), rebalanceDates...)
+	sort.Slice(basket.RebalanceDates, func(i, j int) bool {
+		return basket.RebalanceDates[i].Before(basket.RebalanceDates[j])
+	})
+
+	return basket, nil
+}
+
+// Contains reports whether the token is a constituent of the basket
+func (b Basket) Contains(tokenName string) bool {
+	for _, constituent := range b.Constituents {
+		if constituent.Token == tokenName {
+			return true
+		}
+	}
+	return false
+}
+
+// DueRebalance returns the latest rebalance date after the last rebalance and not after at
+func (b Basket) DueRebalance(rebalancedAt, at time.Time) (time.Time, bool) {
+	var due time.Time
+	for _, date := range b.RebalanceDates {
+		if date.After(rebalancedAt) && !date.After(at) {
+			due = date
+		}
+	}
+	return due, !due.IsZero()
+}
+
+// Holdings gives every constituent units worth its weight of valueUSD at the given quotes
+func (b Basket) Holdings(valueUSD float64, quotesByToken map[string]Quote) ([]BasketHolding, error) {
+	holdings := make([]BasketHolding, len(b.Constituents))
+	for i, constituent := range b.Constituents {
+		quote, ok := quotesByToken[constituent.Token]
+		if !ok || quote.USD <= 0 {
+			return nil, &Error{Kind: ErrNoData, Message: fmt.Sprintf("no USD price for %s, constituent of %s", constituent.Token, b.Name)}
+		}
+		holdings[i] = BasketHolding{
+			Token:          constituent.Token,
+			Weight:         constituent.Weight,
+			Units:          constituent.Weight * valueUSD / quote.USD,
+			PriceUSD:       quote.USD,
+			QuoteTimestamp: quote.Timestamp,
+		}
+	}
+	return holdings, nil
+}
+
+// BasketHolding is the position of a constituent in a basket point
+type BasketHolding struct {
+	Token          string    `json:"token"`
+	Weight         float64   `json:"weight"` // target weight at the last rebalance
+	Units          float64   `json:"units"`
+	PriceUSD       float64   `json:"price_usd"`
+	QuoteTimestamp time.Time `json:"quote_timestamp"`
+}
+
+// BasketSnapshot records the holdings a basket point was computed with
+type BasketSnapshot struct {
+	Basket       string
+	Timestamp    time.Time
+	RebalancedAt time.Time // first point or last rebalance date the units were set at
+	Holdings     []BasketHolding
+}
+
+// Reprice returns the holdings with the same units at the given quotes; every constituent needs a positive USD price
+func Reprice(holdings []BasketHolding, quotesByToken map[string]Quote) ([]BasketHolding, error) {
+	repriced := make([]BasketHolding, len(holdings))
+	for i, holding := range holdings {
+		quote, ok := quotesByToken[holding.Token]
+		if !ok || quote.USD <= 0 {
+			return nil, &Error{Kind: ErrNoData, Message: fmt.Sprintf("no USD price for basket constituent %s", holding.Token)}
+		}
+		holding.PriceUSD = quote.USD
+		holding.QuoteTimestamp = quote.Timestamp
+		repriced[i] = holding
+	}
+	return repriced, nil
+}
+
+// ValueUSD is the USD value of the holdings at their recorded prices
+func ValueUSD(holdings []BasketHolding) float64 {
+	var value float64
+	for _, holding := range holdings {
+		value += holding.Units * holding.PriceUSD
+	}
+	return value
+}
+
+// BasketQuote prices the holdings in every currency at the given quotes. A currency is left at 0
+// when a constituent has no price in it, and marked filled when a constituent price was forward-filled.
+func BasketQuote(holdings []BasketHolding, quotesByToken map[string]Quote, timestamp time.Time) Quote {
+	prices := make(map[Currency]float64)
+	var filled uint16
+	missing := make(map[Currency]bool)
+	for _, holding := range holdings {
+		quote := quotesByToken[holding.Token]
+		filled |= quote.Filled
+		for _, currency := range GetSupportedCurrencies() {
+			price := quote.Price(currency)
+			if price <= 0 {
+				missing[currency] = true
+				continue
+			}
+			prices[currency] += holding.Units * price
+		}
+	}
+	for currency := range missing {
+		prices[currency] = 0
+		filled &^= FilledBit(currency)
+	}
+
+	return Quote{
+		Timestamp: timestamp,
+		BTC:       prices[CurrencyBTC],
+		USD:       prices[CurrencyUSD],
+		EUR:       prices[CurrencyEUR],
+		CNY:       prices[CurrencyCNY],
+		JPY:       prices[CurrencyJPY],
+		KRW:       prices[CurrencyKRW],
+		ETH:       prices[CurrencyETH],
+		GBP:       prices[CurrencyGBP],
+		Filled:    filled,
+	}
+}
@@ -0,0 +1,105 @@
+package quotes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ConversionLeg is a quote price used by a conversion
+type ConversionLeg struct {
+	Token     string
+	Currency  Currency
+	Price     float64
+	Timestamp time.Time
+	// Inverse is set when the conversion goes from the currency to the token
+	Inverse bool
+}
+
+// Conversion is an amount converted between a token and a currency or between two tokens
+type Conversion struct {
+	Amount float64
+	From   string
+	To     string
+	Result float64
+	Rate   float64 // Result per unit of From
+	// Path lists the symbols the conversion goes through, e.g. [mvrk usd usdt]
+	Path []string
+	Legs []ConversionLeg
+}
+
+// IsSymbolSupported checks if a symbol is a supported token or currency
+func IsSymbolSupported(symbol string) bool {
+	return IsTokenSupported(symbol) || IsCurrencySupported(symbol)
+}
+
+// Convert converts amount from one symbol to another with the given quotes, keyed by token.
+// Token-to-token conversions go through the first currency priced in both quotes, USD first.
+// Converting between two currencies is not supported since no quote prices one in the other.
+func Convert(amount float64, from, to string, quotesByToken map[string]Quote) (Conversion, error) {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	conversion := Conversion{Amount: amount, From: from, To: to}
+
+	fromToken, toToken := IsTokenSupported(from), IsTokenSupported(to)
+	switch {
+	case from == to:
+		conversion.Rate = 1
+		conversion.Path = []string{from}
+
+	case fromToken && !toToken:
+		leg, err := newLeg(from, Currency(to), quotesByToken)
+		if err != nil {
+			return Conversion{}, err
+		}
+		conversion.Rate = leg.Price
+		conversion.Path = []string{from, to}
+		conversion.Legs = []ConversionLeg{leg}
+
+	case !fromToken && toToken:
+		leg, err := newLeg(to, Currency(from), quotesByToken)
+		if err != nil {
+			return Conversion{}, err
+		}
+		leg.Inverse = true
+		conversion.Rate = 1 / leg.Price
+		conversion.Path = []string{from, to}
+		conversion.Legs = []ConversionLeg{leg}
+
+	case fromToken && toToken:
+		currency, ok := commonCurrency(quotesByToken[from], quotesByToken[to])
+		if !ok {
+			return Conversion{}, &Error{Kind: ErrNoData, Message: fmt.Sprintf("no currency priced for both '%s' and '%s'", from, to)}
+		}
+		fromLeg, _ := newLeg(from, currency, quotesByToken)
+		toLeg, _ := newLeg(to, currency, quotesByToken)
+		toLeg.Inverse = true
+		conversion.Rate = fromLeg.Price / toLeg.Price
+		conversion.Path = []string{from, string(currency), to}
+		conversion.Legs = []ConversionLeg{fromLeg, toLeg}
+
+	default:
+		return Conversion{}, fmt.Errorf("cannot convert between currencies '%s' and '%s'", from, to)
+	}
+
+	conversion.Result = amount * conversion.Rate
+	return conversion, nil
+}
+
+func newLeg(tokenName string, currency Currency, quotesByToken map[string]Quote) (ConversionLeg, error) {
+	quote, ok := quotesByToken[tokenName]
+	if !ok || quote.Price(currency) <= 0 {
+		return ConversionLeg{}, &Error{Kind: ErrNoData, Message: fmt.Sprintf("no %s price for token '%s'", currency, tokenName)}
+	}
+	return ConversionLeg{Token: tokenName, Currency: currency, Price: quote.Price(currency), Timestamp: quote.Timestamp}, nil
+}
+
+// commonCurrency returns the currency used to convert between two tokens, preferring USD
+func commonCurrency(a, b Quote) (Currency, bool) {
+	candidates := append([]Currency{CurrencyUSD}, GetSupportedCurrencies()...)
+	for _, currency := range candidates {
+		if a.Price(currency) > 0 && b.Price(currency) > 0 {
+			return currency, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package quotes
+
+import "time"
+
+// DailyMethod is the way a single price is picked for a calendar day
+type DailyMethod string
+
+const (
+	// DailyClose is the last observed price of the day
+	DailyClose DailyMethod = "close"
+	// DailyOpen is the first observed price of the day
+	DailyOpen DailyMethod = "open"
+	// DailyAverage is the mean of all observed prices of the day
+	DailyAverage DailyMethod = "average"
+)
+
+// IsDailyMethodSupported checks if a daily method is supported
+func IsDailyMethodSupported(method string) bool {
+	switch DailyMethod(method) {
+	case DailyClose, DailyOpen, DailyAverage:
+		return true
+	default:
+		return false
+	}
+}
+
+// DailyPrice summarizes the observed prices of one calendar day in some time zone
+type DailyPrice struct {
+	// Day is midnight of the day in its time zone
+	Day time.Time
+
+	Open    float64
+	OpenAt  time.Time
+	Close   float64
+	CloseAt time.Time
+	Average float64
+	Points  int64
+}
+
+// Price returns the price of the day for a method
+func (d DailyPrice) Price(method DailyMethod) float64 {
+	switch method {
+	case DailyOpen:
+		return d.Open
+	case DailyAverage:
+		return d.Average
+	default:
+		return d.Close
+	}
+}
+
+// End returns the start of the next day. Days are 23 or 25 hours long across DST changes.
+func (d DailyPrice) End() time.Time {
+	return StartOfDay(d.Day.AddDate(0, 0, 1))
+}
+
+// StartOfDay returns midnight of the calendar day of t, in the location of t
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
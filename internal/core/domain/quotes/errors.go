@@ -0,0 +1,54 @@
+package quotes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of domain errors, match them with errors.Is
+var (
+	ErrTokenNotFound       = errors.New("token not found")
+	ErrNoData              = errors.New("no data")
+	ErrInvalidRange        = errors.New("invalid time range")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrCommitmentMismatch  = errors.New("commitment mismatch")
+)
+
+// Error is a domain error. Message is safe to show to clients, Err is the underlying cause
+// (e.g. a database error) and is only meant for logs.
+type Error struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func TokenNotFound(tokenName string) error {
+	return &Error{Kind: ErrTokenNotFound, Message: fmt.Sprintf("token '%s' is not supported", tokenName)}
+}
+
+func NoData(tokenName string) error {
+	return &Error{Kind: ErrNoData, Message: fmt.Sprintf("no quotes found for token '%s'", tokenName)}
+}
+
+func InvalidRange(message string) error {
+	return &Error{Kind: ErrInvalidRange, Message: message}
+}
+
+// UpstreamUnavailable wraps a failure of a dependency (database, price provider)
+func UpstreamUnavailable(upstream string, err error) error {
+	return &Error{Kind: ErrUpstreamUnavailable, Message: upstream + " unavailable", Err: err}
+}
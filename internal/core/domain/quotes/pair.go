@@ -0,0 +1,42 @@
+package quotes
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PairQuote is a point of a synthetic pair between two stored tokens: the price of the base
+// token in the quote token, derived from both prices in a pivot currency
+type PairQuote struct {
+	Timestamp time.Time `json:"timestamp"` // Timestamp of the base token quote
+	Price     float64   `json:"price"`     // BasePrice / QuotePrice
+	BasePrice float64   `json:"base_price"`
+	// QuotePrice comes from the quote token quote nearest to Timestamp, at QuoteTimestamp
+	QuotePrice     float64   `json:"quote_price"`
+	QuoteTimestamp time.Time `json:"quote_timestamp"`
+}
+
+// NewPairQuote derives a pair point from the prices of both tokens in the pivot currency
+func NewPairQuote(timestamp time.Time, basePrice float64, quoteTimestamp time.Time, quotePrice float64) PairQuote {
+	return PairQuote{
+		Timestamp:      timestamp,
+		Price:          basePrice / quotePrice,
+		BasePrice:      basePrice,
+		QuotePrice:     quotePrice,
+		QuoteTimestamp: quoteTimestamp,
+	}
+}
+
+// MarshalJSON customizes JSON marshaling to ensure timestamps are in UTC format
+func (p PairQuote) MarshalJSON() ([]byte, error) {
+	type Alias PairQuote
+	return json.Marshal(&struct {
+		Timestamp      string `json:"timestamp"`
+		QuoteTimestamp string `json:"quote_timestamp"`
+		*Alias
+	}{
+		Timestamp:      p.Timestamp.UTC().Format("2006-01-02T15:04:05Z"),
+		QuoteTimestamp: p.QuoteTimestamp.UTC().Format("2006-01-02T15:04:05Z"),
+		Alias:          (*Alias)(&p),
+	})
+}
@@ -2,6 +2,7 @@ package quotes
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -15,6 +16,12 @@ type Quote struct {
 	KRW       float64   `json:"krw"`
 	ETH       float64   `json:"eth"`
 	GBP       float64   `json:"gbp"`
+
+	// Volume24h is the rolling 24h trading volume in USD reported with the quote (0 when unknown),
+	// not the volume traded since the previous quote
+	Volume24h float64 `json:"-"`
+	// Filled has a bit set for every currency whose price was forward-filled from an earlier quote
+	Filled uint16 `json:"-"`
 }
 
 // MarshalJSON customizes JSON marshaling to ensure timestamp is in UTC format
@@ -29,6 +36,50 @@ func (q Quote) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// Price returns the quote price in the given currency
+func (q Quote) Price(currency Currency) float64 {
+	switch currency {
+	case CurrencyBTC:
+		return q.BTC
+	case CurrencyUSD:
+		return q.USD
+	case CurrencyEUR:
+		return q.EUR
+	case CurrencyCNY:
+		return q.CNY
+	case CurrencyJPY:
+		return q.JPY
+	case CurrencyKRW:
+		return q.KRW
+	case CurrencyETH:
+		return q.ETH
+	case CurrencyGBP:
+		return q.GBP
+	default:
+		return 0
+	}
+}
+
+// IsFilled reports whether the price in the given currency was forward-filled rather than observed
+func (q Quote) IsFilled(currency Currency) bool {
+	return q.Filled&FilledBit(currency) != 0
+}
+
+// MarkFilled records that the price in the given currency was forward-filled
+func (q *Quote) MarkFilled(currency Currency) {
+	q.Filled |= FilledBit(currency)
+}
+
+// FilledBit is the bit of a currency in Quote.Filled (and the filled column), following GetSupportedCurrencies
+func FilledBit(currency Currency) uint16 {
+	for i, supported := range GetSupportedCurrencies() {
+		if supported == currency {
+			return 1 << i
+		}
+	}
+	return 0
+}
+
 type Currency string
 
 const (
@@ -54,3 +105,8 @@ func GetSupportedCurrencies() []Currency {
 		CurrencyGBP,
 	}
 }
+
+// IsCurrencySupported checks if a currency is supported
+func IsCurrencySupported(name string) bool {
+	return FilledBit(Currency(strings.ToLower(name))) != 0
+}
@@ -0,0 +1,54 @@
+package quotes
+
+import (
+	"math"
+	"time"
+)
+
+// Stats describes the prices of a token in one currency over a time range.
+// Forward-filled prices are not observations and are left out.
+type Stats struct {
+	Currency Currency
+	From     time.Time
+	To       time.Time
+
+	Count  int64
+	Min    float64
+	MinAt  time.Time
+	Max    float64
+	MaxAt  time.Time
+	Mean   float64
+	StdDev float64 // Sample standard deviation of prices
+
+	First   float64
+	FirstAt time.Time
+	Last    float64
+	LastAt  time.Time
+
+	// Volatility is the realized volatility over the range: the square root of the sum of
+	// squared log returns between consecutive quotes
+	Volatility float64
+	// MaxDrawdown is the largest drop from a running peak, as a fraction of the peak
+	MaxDrawdown   float64
+	MaxDrawdownAt time.Time // Time of the trough
+}
+
+const secondsPerYear = 365 * 24 * 3600
+
+// ChangePercent returns the change between the first and the last quote in percent
+func (s Stats) ChangePercent() float64 {
+	if s.First == 0 {
+		return 0
+	}
+	return (s.Last - s.First) / s.First * 100
+}
+
+// AnnualizedVolatility scales the realized volatility to a year, assuming returns keep the
+// same variance per unit of time
+func (s Stats) AnnualizedVolatility() float64 {
+	span := s.LastAt.Sub(s.FirstAt).Seconds()
+	if span <= 0 {
+		return 0
+	}
+	return s.Volatility * math.Sqrt(secondsPerYear/span)
+}
@@ -0,0 +1,42 @@
+package quotes
+
+import "time"
+
+// TickerPeriod is a lookback over which a ticker reports price changes
+type TickerPeriod struct {
+	Name string
+	Span time.Duration
+}
+
+// TickerPeriods are the lookbacks reported by tickers, shortest first
+var TickerPeriods = []TickerPeriod{
+	{Name: "1h", Span: time.Hour},
+	{Name: "24h", Span: 24 * time.Hour},
+	{Name: "7d", Span: 7 * 24 * time.Hour},
+}
+
+// Ticker is the latest quote of a token along with the quotes it is compared to
+type Ticker struct {
+	Token  string
+	Latest Quote
+	// Previous holds, per period name, the last quote at or before Latest.Timestamp minus the
+	// period span. Periods reaching before the first stored quote are missing.
+	Previous map[string]Quote
+}
+
+// Change returns the absolute and percentage change of the price in a currency over a period.
+// ok is false when there is no quote to compare to or either price is missing.
+func (t Ticker) Change(currency Currency, period string) (change, percent float64, ok bool) {
+	previous, found := t.Previous[period]
+	if !found {
+		return 0, 0, false
+	}
+
+	current, past := t.Latest.Price(currency), previous.Price(currency)
+	if current <= 0 || past <= 0 {
+		return 0, 0, false
+	}
+
+	change = current - past
+	return change, change / past * 100, true
+}
@@ -15,6 +15,21 @@ var supportedTokens = map[Token]bool{
 	TokenUSDT: true,
 }
 
+// baskets are tokens computed from other tokens rather than collected
+var baskets = map[Token]bool{}
+
+// RegisterBasket makes a basket a supported token. It must be called at startup, before quotes are served.
+func RegisterBasket(name string) {
+	token := Token(strings.ToLower(name))
+	supportedTokens[token] = true
+	baskets[token] = true
+}
+
+// IsBasket checks if a token is a basket computed from other tokens
+func IsBasket(tokenName string) bool {
+	return baskets[Token(strings.ToLower(tokenName))]
+}
+
 // IsTokenSupported checks if a token is supported
 func IsTokenSupported(tokenName string) bool {
 	return supportedTokens[Token(strings.ToLower(tokenName))]
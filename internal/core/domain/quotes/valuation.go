@@ -0,0 +1,94 @@
+package quotes
+
+import "time"
+
+// ValuationEntry is an amount of a token at a moment, e.g. a staking reward
+type ValuationEntry struct {
+	Timestamp time.Time
+	Amount    float64
+}
+
+// Valuation is an entry valued with the last quote at or before its timestamp
+type Valuation struct {
+	ValuationEntry
+	// Quote is the quote used; its Timestamp is zero when the token had no quote yet
+	Quote  Quote
+	Values map[Currency]float64
+	// MissingCurrencies are the requested currencies the quote has no price in; they have no value
+	MissingCurrencies []Currency
+	// Stale is set when the quote is older than the allowed age, forward-filled, missing or lacks a currency
+	Stale bool
+}
+
+// HasQuote reports whether a quote was found for the entry
+func (v Valuation) HasQuote() bool {
+	return !v.Quote.Timestamp.IsZero()
+}
+
+// QuoteAge returns how old the quote was at the entry timestamp
+func (v Valuation) QuoteAge() time.Duration {
+	return v.Timestamp.Sub(v.Quote.Timestamp)
+}
+
+// ValuationReport is a batch of valued entries with totals
+type ValuationReport struct {
+	Valuations  []Valuation
+	TotalAmount float64
+	// Totals sums the values per currency over entries with a price in it, stale ones included
+	Totals     map[Currency]float64
+	StaleCount int
+	// MissingCount is the number of entries without any quote at or before their timestamp
+	MissingCount int
+}
+
+// Valuate values entries in currencies with quotesAt[i], the last quote at or before
+// entries[i].Timestamp (zero when none). Quotes older than maxAge, or whose price was forward-filled
+// in one of the currencies, are flagged as stale. A currency the quote has no price in is left out of
+// the values and totals, and flags the entry as stale too.
+func Valuate(entries []ValuationEntry, quotesAt []Quote, currencies []Currency, maxAge time.Duration) ValuationReport {
+	report := ValuationReport{
+		Valuations: make([]Valuation, len(entries)),
+		Totals:     make(map[Currency]float64, len(currencies)),
+	}
+	for _, currency := range currencies {
+		report.Totals[currency] = 0
+	}
+
+	for i, entry := range entries {
+		valuation := Valuation{ValuationEntry: entry, Values: make(map[Currency]float64, len(currencies))}
+		if i < len(quotesAt) {
+			valuation.Quote = quotesAt[i]
+		}
+		report.TotalAmount += entry.Amount
+
+		if !valuation.HasQuote() {
+			valuation.Stale = true
+			report.StaleCount++
+			report.MissingCount++
+			report.Valuations[i] = valuation
+			continue
+		}
+
+		valuation.Stale = valuation.QuoteAge() > maxAge
+		for _, currency := range currencies {
+			if valuation.Quote.IsFilled(currency) {
+				valuation.Stale = true
+			}
+			price := valuation.Quote.Price(currency)
+			if price <= 0 {
+				valuation.MissingCurrencies = append(valuation.MissingCurrencies, currency)
+				valuation.Stale = true
+				continue
+			}
+			value := entry.Amount * price
+			valuation.Values[currency] = value
+			report.Totals[currency] += value
+		}
+		if valuation.Stale {
+			report.StaleCount++
+		}
+		report.Valuations[i] = valuation
+	}
+
+	return report
+}
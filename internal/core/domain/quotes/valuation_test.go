@@ -0,0 +1,64 @@
+package quotes
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestValuate(t *testing.T) {
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []ValuationEntry{
+		{Timestamp: at, Amount: 10},
+		{Timestamp: at.Add(time.Hour), Amount: 20},
+		{Timestamp: at.Add(2 * time.Hour), Amount: 30},
+		{Timestamp: at.Add(3 * time.Hour), Amount: 40},
+	}
+	filled := Quote{Timestamp: at.Add(2*time.Hour - time.Minute), USD: 0.3, EUR: 0.25}
+	filled.MarkFilled(CurrencyEUR)
+	quotesAt := []Quote{
+		{Timestamp: at.Add(-time.Minute), USD: 0.1, EUR: 0.09},
+		{Timestamp: at.Add(time.Hour - time.Minute), USD: 0.2}, // no EUR price
+		filled,
+		{}, // no quote yet
+	}
+
+	report := Valuate(entries, quotesAt, []Currency{CurrencyUSD, CurrencyEUR}, 5*time.Minute)
+
+	tests := []struct {
+		values  map[Currency]float64
+		missing []Currency
+		stale   bool
+	}{
+		{map[Currency]float64{CurrencyUSD: 1, CurrencyEUR: 0.9}, nil, false},
+		{map[Currency]float64{CurrencyUSD: 4}, []Currency{CurrencyEUR}, true},
+		{map[Currency]float64{CurrencyUSD: 9, CurrencyEUR: 7.5}, nil, true},
+		{map[Currency]float64{}, nil, true},
+	}
+	for i, tt := range tests {
+		valuation := report.Valuations[i]
+		if valuation.Stale != tt.stale || !slices.Equal(valuation.MissingCurrencies, tt.missing) {
+			t.Errorf("entry %d: stale %v, missing %v; want %v, %v", i, valuation.Stale, valuation.MissingCurrencies, tt.stale, tt.missing)
+		}
+		if len(valuation.Values) != len(tt.values) {
+			t.Errorf("entry %d: values %v, want %v", i, valuation.Values, tt.values)
+		}
+		for currency, want := range tt.values {
+			if got, ok := valuation.Values[currency]; !ok || !almostEqual(got, want) {
+				t.Errorf("entry %d: %s value %v, want %v", i, currency, got, want)
+			}
+		}
+	}
+
+	if !almostEqual(report.Totals[CurrencyUSD], 14) || !almostEqual(report.Totals[CurrencyEUR], 8.4) {
+		t.Errorf("totals = %v", report.Totals)
+	}
+	if report.TotalAmount != 100 || report.StaleCount != 3 || report.MissingCount != 1 {
+		t.Errorf("total amount %v, stale %d, missing %d", report.TotalAmount, report.StaleCount, report.MissingCount)
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	return a-b < epsilon && b-a < epsilon
+}
@@ -0,0 +1,277 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"maps"
+	"quotes/internal/config"
+	"quotes/internal/core/domain/quotes"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// loadTimeout bounds a repository load shared by collapsed callers, which no longer follows any caller's context
+const loadTimeout = 30 * time.Second
+
+type Repository interface {
+	GetLastQuote(ctx context.Context, tokenName string) (quotes.Quote, error)
+	GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error)
+	GetTicker(ctx context.Context, tokenName string) (quotes.Ticker, error)
+	GetQuotesAt(ctx context.Context, tokenName string, timestamps []time.Time) ([]quotes.Quote, error)
+}
+
+// window is a cached GetQuotes result
+type window struct {
+	key       string
+	tokenName string
+	from, to  time.Time
+	quotes    []quotes.Quote
+}
+
+// QuoteCache sits between the read actions and the repository.
+// It keeps the latest quote and ticker per token hot, caches immutable historical windows
+// and "latest N" queries in an LRU, and collapses concurrent identical queries
+// into a single repository call. The collector keeps it up to date through OnQuotesSaved.
+type QuoteCache struct {
+	repo       Repository
+	enabled    bool
+	maxWindows int
+	finality   time.Duration
+
+	mu          sync.Mutex
+	latest      map[string]quotes.Quote
+	tickers     map[string]quotes.Ticker
+	windows     map[string]*list.Element
+	lru         *list.List
+	generations map[string]uint64
+
+	group singleflight.Group
+}
+
+func NewQuoteCache(cfg *config.Config, repo Repository) *QuoteCache {
+	return &QuoteCache{
+		repo:        repo,
+		enabled:     cfg.Cache.Enabled,
+		maxWindows:  cfg.Cache.MaxWindows,
+		finality:    cfg.GetFinalityHorizon(),
+		latest:      make(map[string]quotes.Quote),
+		tickers:     make(map[string]quotes.Ticker),
+		windows:     make(map[string]*list.Element),
+		lru:         list.New(),
+		generations: make(map[string]uint64),
+	}
+}
+
+// GetLastQuote returns the latest quote for a token, loading it once from the repository
+func (c *QuoteCache) GetLastQuote(ctx context.Context, tokenName string) (quotes.Quote, error) {
+	if !c.enabled {
+		return c.repo.GetLastQuote(ctx, tokenName)
+	}
+
+	tokenName = strings.ToLower(tokenName)
+
+	c.mu.Lock()
+	quote, ok := c.latest[tokenName]
+	generation := c.generations[tokenName]
+	c.mu.Unlock()
+	if ok {
+		return quote, nil
+	}
+
+	v, err := c.load(ctx, "latest|"+tokenName, func(ctx context.Context) (interface{}, error) {
+		return c.repo.GetLastQuote(ctx, tokenName)
+	})
+	if err != nil {
+		return quotes.Quote{}, err
+	}
+	quote = v.(quotes.Quote)
+
+	c.mu.Lock()
+	// Don't overwrite a newer quote pushed by the collector while we were loading
+	if c.generations[tokenName] == generation {
+		c.latest[tokenName] = quote
+	}
+	c.mu.Unlock()
+
+	return quote, nil
+}
+
+// GetTicker returns the ticker of a token. It is kept until the collector saves new quotes
+// for the token, since the ticker only moves with the latest quote.
+func (c *QuoteCache) GetTicker(ctx context.Context, tokenName string) (quotes.Ticker, error) {
+	if !c.enabled {
+		return c.repo.GetTicker(ctx, tokenName)
+	}
+
+	tokenName = strings.ToLower(tokenName)
+
+	c.mu.Lock()
+	ticker, ok := c.tickers[tokenName]
+	generation := c.generations[tokenName]
+	c.mu.Unlock()
+	if ok {
+		return copyTicker(ticker), nil
+	}
+
+	v, err := c.load(ctx, "ticker|"+tokenName, func(ctx context.Context) (interface{}, error) {
+		return c.repo.GetTicker(ctx, tokenName)
+	})
+	if err != nil {
+		return quotes.Ticker{}, err
+	}
+	ticker = v.(quotes.Ticker)
+
+	c.mu.Lock()
+	if c.generations[tokenName] == generation {
+		c.tickers[tokenName] = ticker
+	}
+	c.mu.Unlock()
+
+	return copyTicker(ticker), nil
+}
+
+// GetQuotesAt returns the last quote of a token at or before every timestamp. Point lookups go to the repository.
+func (c *QuoteCache) GetQuotesAt(ctx context.Context, tokenName string, timestamps []time.Time) ([]quotes.Quote, error) {
+	return c.repo.GetQuotesAt(ctx, tokenName, timestamps)
+}
+
+// GetQuotes returns quotes for a token. Windows that can no longer change (ending before the
+// finality horizon) and "latest N" queries are served from the LRU; everything else goes to
+// the repository with concurrent identical queries collapsed.
+func (c *QuoteCache) GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error) {
+	if !c.enabled {
+		return c.repo.GetQuotes(ctx, from, to, limit, tokenName)
+	}
+
+	tokenName = strings.ToLower(tokenName)
+	key := fmt.Sprintf("quotes|%s|%d|%d|%d", tokenName, from.UnixNano(), to.UnixNano(), limit)
+	cacheable := c.isCacheable(from, to)
+
+	c.mu.Lock()
+	if cacheable {
+		if el, ok := c.windows[key]; ok {
+			c.lru.MoveToFront(el)
+			result := el.Value.(*window).quotes
+			c.mu.Unlock()
+			return slices.Clone(result), nil
+		}
+	}
+	generation := c.generations[tokenName]
+	c.mu.Unlock()
+
+	v, err := c.load(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return c.repo.GetQuotes(ctx, from, to, limit, tokenName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := v.([]quotes.Quote)
+
+	if cacheable {
+		c.mu.Lock()
+		if c.generations[tokenName] == generation {
+			c.store(&window{key: key, tokenName: tokenName, from: from, to: to, quotes: result})
+		}
+		c.mu.Unlock()
+	}
+
+	// The loaded slice is shared by every collapsed caller and the cache: callers get their own copy
+	return slices.Clone(result), nil
+}
+
+// load runs fn once for concurrent callers of the same key. fn runs detached from the caller's
+// cancellation, so a client going away does not fail the others; each caller still stops waiting
+// when its own ctx is done.
+func (c *QuoteCache) load(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	result := c.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		return fn(loadCtx)
+	})
+
+	select {
+	case res := <-result:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func copyTicker(ticker quotes.Ticker) quotes.Ticker {
+	ticker.Previous = maps.Clone(ticker.Previous)
+	return ticker
+}
+
+// OnQuotesSaved refreshes the latest quote and drops the ticker and cached windows affected by the new quotes
+func (c *QuoteCache) OnQuotesSaved(tokenName string, quotesList []quotes.Quote) {
+	if !c.enabled || len(quotesList) == 0 {
+		return
+	}
+
+	tokenName = strings.ToLower(tokenName)
+
+	first, last := quotesList[0], quotesList[0]
+	for _, quote := range quotesList[1:] {
+		if quote.Timestamp.Before(first.Timestamp) {
+			first = quote
+		}
+		if quote.Timestamp.After(last.Timestamp) {
+			last = quote
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[tokenName]++
+	delete(c.tickers, tokenName)
+
+	if current, ok := c.latest[tokenName]; !ok || !last.Timestamp.Before(current.Timestamp) {
+		c.latest[tokenName] = last
+	}
+
+	for key, el := range c.windows {
+		w := el.Value.(*window)
+		if w.tokenName != tokenName {
+			continue
+		}
+		// "latest N" windows always move; time windows only if the new quotes fall inside them
+		latestQuery := w.from.IsZero() && w.to.IsZero()
+		if latestQuery || (!last.Timestamp.Before(w.from) && !first.Timestamp.After(w.to)) {
+			c.lru.Remove(el)
+			delete(c.windows, key)
+		}
+	}
+}
+
+func (c *QuoteCache) isCacheable(from, to time.Time) bool {
+	if c.maxWindows <= 0 {
+		return false
+	}
+	if from.IsZero() && to.IsZero() {
+		return true
+	}
+	return !to.IsZero() && to.Before(time.Now().Add(-c.finality))
+}
+
+// store adds a window to the LRU, evicting the least recently used one when full. Caller holds c.mu.
+func (c *QuoteCache) store(w *window) {
+	if el, ok := c.windows[w.key]; ok {
+		el.Value = w
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.windows[w.key] = c.lru.PushFront(w)
+
+	for c.lru.Len() > c.maxWindows {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.windows, oldest.Value.(*window).key)
+	}
+}
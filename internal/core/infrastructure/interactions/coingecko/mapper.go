@@ -11,7 +11,8 @@ type PriceData struct {
 }
 
 // MapToQuotes converts CoinGecko API response to domain quotes
-// It normalizes data to seconds using forward-fill strategy
+// It normalizes data to seconds using forward-fill strategy; forward-filled prices are marked
+// on the quote. The USD volume is attached where CoinGecko reports one and never forward-filled.
 func MapToQuotes(currencyData map[string]*MarketChartRangeResponse) ([]quotes.Quote, error) {
 	if len(currencyData) == 0 {
 		return nil, nil
@@ -55,6 +56,16 @@ func MapToQuotes(currencyData map[string]*MarketChartRangeResponse) ([]quotes.Qu
 		priceMaps[currency] = priceMap
 	}
 
+	// Volumes are taken from the USD chart only
+	volumeMap := make(map[int64]float64)
+	if data, exists := currencyData[string(quotes.CurrencyUSD)]; exists && data != nil {
+		for _, volume := range data.TotalVolume {
+			if len(volume) >= 2 {
+				volumeMap[int64(volume[0]/1000)] = volume[1]
+			}
+		}
+	}
+
 	// Create quotes with forward-fill
 	var result []quotes.Quote
 	var lastQuote *quotes.Quote
@@ -62,6 +73,7 @@ func MapToQuotes(currencyData map[string]*MarketChartRangeResponse) ([]quotes.Qu
 	for _, timestamp := range timestamps {
 		quote := quotes.Quote{
 			Timestamp: time.Unix(timestamp, 0).UTC(),
+			Volume24h: volumeMap[timestamp],
 		}
 
 		// Fill prices for each currency using forward-fill
@@ -73,7 +85,8 @@ func MapToQuotes(currencyData map[string]*MarketChartRangeResponse) ([]quotes.Qu
 					setQuotePrice(&quote, currency, price)
 				} else if lastQuote != nil {
 					// Forward-fill from last quote
-					setQuotePrice(&quote, currency, getQuotePrice(*lastQuote, currency))
+					setQuotePrice(&quote, currency, lastQuote.Price(currency))
+					quote.MarkFilled(currency)
 				}
 			}
 		}
@@ -109,29 +122,6 @@ func setQuotePrice(quote *quotes.Quote, currency quotes.Currency, price float64)
 	}
 }
 
-func getQuotePrice(quote quotes.Quote, currency quotes.Currency) float64 {
-	switch currency {
-	case quotes.CurrencyBTC:
-		return quote.BTC
-	case quotes.CurrencyUSD:
-		return quote.USD
-	case quotes.CurrencyEUR:
-		return quote.EUR
-	case quotes.CurrencyCNY:
-		return quote.CNY
-	case quotes.CurrencyJPY:
-		return quote.JPY
-	case quotes.CurrencyKRW:
-		return quote.KRW
-	case quotes.CurrencyETH:
-		return quote.ETH
-	case quotes.CurrencyGBP:
-		return quote.GBP
-	default:
-		return 0
-	}
-}
-
 func hasAnyPrice(quote quotes.Quote) bool {
 	return quote.BTC != 0 || quote.USD != 0 || quote.EUR != 0 ||
 		quote.CNY != 0 || quote.JPY != 0 || quote.KRW != 0 ||
@@ -0,0 +1,146 @@
+package mavryk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotRevealed is returned when an account has not revealed its public key yet
+var ErrNotRevealed = errors.New("account public key is not revealed")
+
+// Client calls the RPC of a Mavryk node (the Tezos node RPC)
+type Client struct {
+	baseURL string
+	chain   string
+	http    *http.Client
+}
+
+func NewClient(baseURL, chain string, timeout time.Duration) *Client {
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	if chain == "" {
+		chain = "main"
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		chain:   chain,
+		http: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Branch returns the hash of the block two levels below the head, a safe anchor for new operations
+func (c *Client) Branch(ctx context.Context) (string, error) {
+	var hash string
+	if err := c.do(ctx, http.MethodGet, c.chainPath("/blocks/head~2/hash"), nil, &hash); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// HeadLevel returns the level of the head block
+func (c *Client) HeadLevel(ctx context.Context) (int64, error) {
+	var header struct {
+		Level int64 `json:"level"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.chainPath("/blocks/head/header"), nil, &header); err != nil {
+		return 0, err
+	}
+	return header.Level, nil
+}
+
+// ManagerOperationHashes returns the hashes of the manager operations (validation pass 3) of the block at level
+func (c *Client) ManagerOperationHashes(ctx context.Context, level int64) ([]string, error) {
+	var hashes []string
+	path := c.chainPath("/blocks/" + strconv.FormatInt(level, 10) + "/operation_hashes/3")
+	if err := c.do(ctx, http.MethodGet, path, nil, &hashes); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// Counter returns the current counter of an implicit account
+func (c *Client) Counter(ctx context.Context, address string) (int64, error) {
+	var counter string
+	if err := c.do(ctx, http.MethodGet, c.chainPath("/blocks/head/context/contracts/"+address+"/counter"), nil, &counter); err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(counter, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid counter %q: %w", counter, err)
+	}
+	return value, nil
+}
+
+// CheckRevealed fails with ErrNotRevealed when the account cannot sign manager operations yet
+func (c *Client) CheckRevealed(ctx context.Context, address string) error {
+	var managerKey *string
+	if err := c.do(ctx, http.MethodGet, c.chainPath("/blocks/head/context/contracts/"+address+"/manager_key"), nil, &managerKey); err != nil {
+		return err
+	}
+	if managerKey == nil {
+		return ErrNotRevealed
+	}
+	return nil
+}
+
+// Inject injects a signed operation and returns its hash
+func (c *Client) Inject(ctx context.Context, signed []byte) (string, error) {
+	body, err := json.Marshal(hex.EncodeToString(signed))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	var hash string
+	if err := c.do(ctx, http.MethodPost, "/injection/operation?chain="+c.chain, body, &hash); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+func (c *Client) chainPath(path string) string {
+	return "/chains/" + c.chain + path
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte, result any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach node: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			log.Printf("error closing response body: %v", cerr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		// The node describes rejected operations in the body (e.g., counter_in_the_past)
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return fmt.Errorf("node returned status %d for %s: %s", resp.StatusCode, path, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode node response for %s: %w", path, err)
+	}
+	return nil
+}
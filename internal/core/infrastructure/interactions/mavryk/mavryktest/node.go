@@ -0,0 +1,120 @@
+// Package mavryktest serves the node RPC endpoints the oracle pusher uses, for local runs and tests
+// without a Mavryk node.
+package mavryktest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"quotes/internal/core/infrastructure/signing"
+	"strconv"
+	"sync"
+)
+
+// genesisBlockHash is a well-formed block hash used as the branch of every operation
+const genesisBlockHash = "BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2"
+
+// Node bakes a block for every injected operation. Operations are checked for consecutive counters
+// and never validated otherwise; every account shares one counter and is considered revealed.
+type Node struct {
+	mu      sync.Mutex
+	counter int64
+	blocks  [][]string // operation hashes of each block, by level
+	mux     *http.ServeMux
+}
+
+func NewNode() *Node {
+	n := &Node{blocks: [][]string{nil}} // level 0 is the genesis block
+	n.mux = http.NewServeMux()
+	n.mux.HandleFunc("GET /chains/{chain}/blocks/head~2/hash", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, genesisBlockHash)
+	})
+	n.mux.HandleFunc("GET /chains/{chain}/blocks/head/header", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int64{"level": n.HeadLevel()})
+	})
+	n.mux.HandleFunc("GET /chains/{chain}/blocks/{level}/operation_hashes/3", n.operationHashes)
+	n.mux.HandleFunc("GET /chains/{chain}/blocks/head/context/contracts/{address}/counter", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, strconv.FormatInt(n.Counter(), 10))
+	})
+	n.mux.HandleFunc("GET /chains/{chain}/blocks/head/context/contracts/{address}/manager_key", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav")
+	})
+	n.mux.HandleFunc("POST /injection/operation", n.inject)
+	return n
+}
+
+// ServeHTTP implements http.Handler
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mux.ServeHTTP(w, r)
+}
+
+// Counter returns the counter of the last injected transaction
+func (n *Node) Counter() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.counter
+}
+
+// HeadLevel returns the level of the last baked block
+func (n *Node) HeadLevel() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return int64(len(n.blocks) - 1)
+}
+
+func (n *Node) operationHashes(w http.ResponseWriter, r *http.Request) {
+	level, err := strconv.ParseInt(r.PathValue("level"), 10, 64)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err != nil || level < 0 || level >= int64(len(n.blocks)) {
+		http.Error(w, "unknown block", http.StatusNotFound)
+		return
+	}
+	hashes := n.blocks[level]
+	if hashes == nil {
+		hashes = []string{}
+	}
+	writeJSON(w, hashes)
+}
+
+func (n *Node) inject(w http.ResponseWriter, r *http.Request) {
+	var encoded string
+	if err := json.NewDecoder(r.Body).Decode(&encoded); err != nil {
+		http.Error(w, "expected a hex string", http.StatusBadRequest)
+		return
+	}
+	signed, err := hex.DecodeString(encoded)
+	if err != nil {
+		http.Error(w, "expected a hex string", http.StatusBadRequest)
+		return
+	}
+	counters, err := signing.TransactionCounters(signed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, counter := range counters {
+		if expected := n.counter + int64(i) + 1; counter != expected {
+			http.Error(w, fmt.Sprintf("counter %d of operation %d, expected %d", counter, i, expected), http.StatusInternalServerError)
+			return
+		}
+	}
+	n.counter += int64(len(counters))
+
+	hash := signing.OperationHash(signed)
+	n.blocks = append(n.blocks, []string{hash})
+	log.Printf("Injected %s with %d operations (%d bytes) in block %d: %s", hash, len(counters), len(signed), len(n.blocks)-1, encoded)
+	writeJSON(w, hash)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("error writing response: %v", err)
+	}
+}
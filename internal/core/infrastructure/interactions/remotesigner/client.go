@@ -0,0 +1,161 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"quotes/internal/core/infrastructure/signing"
+	"strings"
+	"sync"
+	"time"
+)
+
+const retryDelay = 200 * time.Millisecond
+
+type publicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+type signatureResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Client talks to a signer speaking the Tezos remote signer HTTP protocol (octez-signer and compatible):
+// GET /keys/<pkh> returns the public key, POST /keys/<pkh> with the hex-encoded bytes returns their signature.
+// The signer must accept the 0x05 (packed data) magic byte, and 0x03 (operations) for the oracle pusher.
+// Signatures are checked against the public key fetched first, so a signer that switched keys or returns
+// corrupted signatures is detected before they are served or injected.
+type Client struct {
+	baseURL string
+	keyHash string
+	retries int
+	http    *http.Client
+
+	mu        sync.Mutex
+	publicKey string // the key of the first successful PublicKey call
+}
+
+func NewClient(baseURL, keyHash string, timeout time.Duration, retries int) *Client {
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		keyHash: keyHash,
+		retries: retries,
+		http: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// PublicKey returns the public key of the configured key hash
+func (c *Client) PublicKey(ctx context.Context) (string, error) {
+	var result publicKeyResponse
+	if err := c.do(ctx, http.MethodGet, nil, &result); err != nil {
+		return "", err
+	}
+	if result.PublicKey == "" {
+		return "", fmt.Errorf("signer returned no public key for %s", c.keyHash)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.publicKey == "" {
+		c.publicKey = result.PublicKey
+	}
+	return result.PublicKey, nil
+}
+
+// Sign has the signer sign message; the signer hashes it with Blake2b-256 itself. The signature is
+// verified against the startup public key before it is returned.
+func (c *Client) Sign(ctx context.Context, message []byte) (string, error) {
+	c.mu.Lock()
+	publicKey := c.publicKey
+	c.mu.Unlock()
+	if publicKey == "" {
+		var err error
+		if publicKey, err = c.PublicKey(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	body, err := json.Marshal(hex.EncodeToString(message))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var result signatureResponse
+	if err := c.do(ctx, http.MethodPost, body, &result); err != nil {
+		return "", err
+	}
+	if result.Signature == "" {
+		return "", fmt.Errorf("signer returned no signature for %s", c.keyHash)
+	}
+	if err := signing.Verify(publicKey, message, result.Signature); err != nil {
+		return "", fmt.Errorf("signer returned an invalid signature for %s: %w", c.keyHash, err)
+	}
+	return result.Signature, nil
+}
+
+// do sends a request to /keys/<pkh>, retrying network errors, 429 and 5xx responses with a growing delay
+func (c *Client) do(ctx context.Context, method string, body []byte, result any) error {
+	var lastErr error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryDelay << (attempt - 1)):
+			}
+			log.Printf("Retrying remote signer request (attempt %d/%d): %v", attempt, c.retries, lastErr)
+		}
+
+		retry, err := c.request(ctx, method, body, result)
+		if err == nil {
+			return nil
+		}
+		if !retry {
+			return err
+		}
+		lastErr = err
+	}
+	return lastErr
+}
+
+func (c *Client) request(ctx context.Context, method string, body []byte, result any) (retry bool, err error) {
+	url := fmt.Sprintf("%s/keys/%s", c.baseURL, c.keyHash)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("failed to reach signer: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			log.Printf("error closing response body: %v", cerr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retry, fmt.Errorf("signer returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return false, fmt.Errorf("failed to decode signer response: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,69 @@
+package remotesigner
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"quotes/internal/core/infrastructure/signing"
+	"testing"
+	"time"
+)
+
+// fakeSigner serves the public key of one key and signs with another
+func fakeSigner(t *testing.T, publicKey, signingKey *signing.LocalSigner) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if r.Method == http.MethodGet {
+			key, _ := publicKey.PublicKey(ctx)
+			_ = json.NewEncoder(w).Encode(publicKeyResponse{PublicKey: key})
+			return
+		}
+
+		var body string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		message, _ := hex.DecodeString(body)
+		signature, _ := signingKey.Sign(ctx, message)
+		_ = json.NewEncoder(w).Encode(signatureResponse{Signature: signature})
+	}))
+}
+
+func TestSignVerifiesSignatures(t *testing.T) {
+	key, err := signing.NewLocalSigner("edsk3gUfUPyBSfrS9CCgmCiQsTCHGkviBDusMxDJstFtojtc1zcpsh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := signing.NewLocalSigner("edsk39qAm1fiMjgmPkw1EgQYkMzkJezLNewd7PLNHTkr6w9XA2zdfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		signingKey *signing.LocalSigner
+		wantErr    bool
+	}{
+		{"same key", key, false},
+		{"switched key", otherKey, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fakeSigner(t, key, tt.signingKey)
+			defer server.Close()
+
+			client := NewClient(server.URL, "mv18Cw7psUrAAPBpXYd9CtCpHg9EgjHP9KTe", time.Second, 0)
+			ctx := context.Background()
+			if _, err := client.PublicKey(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			signature, err := client.Sign(ctx, []byte{0x05, 0x00, 0x01})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Sign() = %q, %v", signature, err)
+			}
+		})
+	}
+}
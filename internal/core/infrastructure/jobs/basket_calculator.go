@@ -0,0 +1,173 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"quotes/internal/config"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/storage/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBasketBaseValue = 100
+	basketComputeTimeout   = 30 * time.Second
+)
+
+// LoadBaskets builds the baskets defined in the configuration, sorted by name
+func LoadBaskets(cfg *config.Config) ([]quotes.Basket, error) {
+	names := make([]string, 0, len(cfg.Baskets))
+	for name := range cfg.Baskets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	baskets := make([]quotes.Basket, 0, len(names))
+	for _, name := range names {
+		basketCfg := cfg.Baskets[name]
+		baseValue := basketCfg.BaseValue
+		if baseValue == 0 {
+			baseValue = defaultBasketBaseValue
+		}
+
+		dates := make([]time.Time, 0, len(basketCfg.RebalanceDates))
+		for _, value := range basketCfg.RebalanceDates {
+			date, err := config.ParseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("basket %q: invalid rebalance date %q", name, value)
+			}
+			dates = append(dates, date)
+		}
+
+		basket, err := quotes.NewBasket(name, baseValue, basketCfg.Constituents, dates)
+		if err != nil {
+			return nil, err
+		}
+		baskets = append(baskets, basket)
+	}
+	return baskets, nil
+}
+
+// BasketCalculator computes a basket point whenever quotes of one of its constituents are saved
+type BasketCalculator struct {
+	baskets   []quotes.Basket
+	quotes    *repositories.QuoteRepository
+	snapshots *repositories.BasketRepository
+	listeners []QuotesListener
+	maxSkew   map[string]time.Duration // per basket: how far apart the latest constituent quotes may be
+
+	mu sync.Mutex // serializes computations so a point is never computed twice
+}
+
+func NewBasketCalculator(cfg *config.Config, db *gorm.DB, baskets []quotes.Basket, listeners ...QuotesListener) *BasketCalculator {
+	// Constituents collected less often lag by up to their own interval
+	maxSkew := make(map[string]time.Duration, len(baskets))
+	for _, basket := range baskets {
+		var skew time.Duration
+		for _, constituent := range basket.Constituents {
+			interval := cfg.GetTokenInterval(constituent.Token)
+			if interval == 0 {
+				interval = cfg.GetJobInterval()
+			}
+			skew = max(skew, interval)
+		}
+		maxSkew[basket.Name] = skew
+	}
+
+	return &BasketCalculator{
+		baskets:   baskets,
+		quotes:    repositories.NewQuoteRepository(db),
+		snapshots: repositories.NewBasketRepository(db),
+		listeners: listeners,
+		maxSkew:   maxSkew,
+	}
+}
+
+// OnQuotesSaved implements QuotesListener
+func (b *BasketCalculator) OnQuotesSaved(tokenName string, quotesList []quotes.Quote) {
+	if len(quotesList) == 0 {
+		return
+	}
+
+	for _, basket := range b.baskets {
+		if !basket.Contains(tokenName) {
+			continue
+		}
+		if err := b.compute(basket); err != nil {
+			log.Printf("Failed to compute basket %s: %v", basket.Name, err)
+		}
+	}
+}
+
+// compute adds a basket point from the latest quotes of its constituents, once they are all
+// within maxSkew of each other and newer than the latest point
+func (b *BasketCalculator) compute(basket quotes.Basket) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), basketComputeTimeout)
+	defer cancel()
+
+	latest := make(map[string]quotes.Quote, len(basket.Constituents))
+	var oldest, newest time.Time
+	for _, constituent := range basket.Constituents {
+		quote, err := b.quotes.GetLastQuote(ctx, constituent.Token)
+		if errors.Is(err, quotes.ErrNoData) {
+			return nil // not collected yet
+		}
+		if err != nil {
+			return err
+		}
+		latest[constituent.Token] = quote
+		if oldest.IsZero() || quote.Timestamp.Before(oldest) {
+			oldest = quote.Timestamp
+		}
+		if quote.Timestamp.After(newest) {
+			newest = quote.Timestamp
+		}
+	}
+	if newest.Sub(oldest) > b.maxSkew[basket.Name] {
+		return nil // wait for the lagging constituents
+	}
+
+	previous, err := b.snapshots.GetLastSnapshot(ctx, basket.Name)
+	if err != nil && !errors.Is(err, quotes.ErrNoData) {
+		return err
+	}
+	if err == nil && !newest.After(previous.Timestamp) {
+		return nil // already computed
+	}
+
+	snapshot := quotes.BasketSnapshot{Basket: basket.Name, Timestamp: newest}
+	switch {
+	case err != nil:
+		snapshot.RebalancedAt = newest
+		snapshot.Holdings, err = basket.Holdings(basket.BaseValue, latest)
+	default:
+		snapshot.RebalancedAt = previous.RebalancedAt
+		snapshot.Holdings, err = quotes.Reprice(previous.Holdings, latest)
+		if due, ok := basket.DueRebalance(previous.RebalancedAt, newest); ok && err == nil {
+			snapshot.RebalancedAt = due
+			snapshot.Holdings, err = basket.Holdings(quotes.ValueUSD(snapshot.Holdings), latest)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	quote := quotes.BasketQuote(snapshot.Holdings, latest, newest)
+	if err := b.snapshots.SavePoint(ctx, quote, snapshot); err != nil {
+		return err
+	}
+
+	for _, listener := range b.listeners {
+		listener.OnQuotesSaved(basket.Name, []quotes.Quote{quote})
+	}
+	return nil
+}
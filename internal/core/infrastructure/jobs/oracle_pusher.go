@@ -0,0 +1,322 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"quotes/internal/config"
+	"quotes/internal/core/application/oracle/get_attestation"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/interactions/mavryk"
+	"quotes/internal/core/infrastructure/signing"
+	"quotes/internal/core/infrastructure/storage/repositories"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Minimal fees accepted by default node mempools: 100 mutez, plus 0.1 mutez per gas unit and 1 mutez per byte
+const (
+	minimalFeeMutez   = 100
+	gasUnitsPerMutez  = 10
+	feeMarginMutez    = 10
+	oraclePushTimeout = time.Minute
+)
+
+// operationTTL bounds how many levels after its injection an operation can still be included
+// (max_operations_ttl), past which a pending push is considered failed
+const operationTTL = 240
+
+type oracleFeed struct {
+	token    string
+	currency quotes.Currency
+}
+
+// oraclePushes records pushes; implemented by repositories.OraclePushRepository
+type oraclePushes interface {
+	Save(ctx context.Context, push oracle.Push) error
+	GetLast(ctx context.Context, contract, tokenName string, currency quotes.Currency) (*oracle.Push, error)
+	GetPending(ctx context.Context, contract string) ([]oracle.Push, error)
+	SetStatus(ctx context.Context, operationHash string, status oracle.PushStatus, level int64) error
+}
+
+// OraclePusher submits attestations to an oracle contract when a price deviates from the last
+// pushed one or the heartbeat elapses. Due feeds are submitted together in one operation, recorded as
+// pending until it is found in a block: a feed waits for its pending push before being pushed again.
+type OraclePusher struct {
+	config       *config.Config
+	signer       *signing.Signer
+	node         *mavryk.Client
+	attestations *get_attestation.Action
+	pushes       oraclePushes
+	policy       oracle.PushPolicy
+	feeds        []oracleFeed
+
+	stopOnce sync.Once
+	stopping chan struct{} // closed by Stop to cancel pushing
+	done     chan struct{} // closed by Run once it has returned
+}
+
+func NewOraclePusher(cfg *config.Config, db *gorm.DB, signer *signing.Signer) (*OraclePusher, error) {
+	return newOraclePusher(cfg, signer, repositories.NewQuoteRepository(db), repositories.NewOraclePushRepository(db))
+}
+
+func newOraclePusher(cfg *config.Config, signer *signing.Signer, quoteRepo get_attestation.Repository, pushes oraclePushes) (*OraclePusher, error) {
+	pusherCfg := cfg.Oracle.Pusher
+	if pusherCfg.NodeURL == "" || pusherCfg.Contract == "" {
+		return nil, fmt.Errorf("the oracle pusher needs oracle.pusher.node_url and oracle.pusher.contract")
+	}
+	if len(pusherCfg.Feeds) == 0 {
+		return nil, fmt.Errorf("the oracle pusher has no feeds")
+	}
+	if cfg.GetPusherDeviationPercent() < 0 {
+		return nil, fmt.Errorf("invalid oracle.pusher.deviation_percent %v", cfg.GetPusherDeviationPercent())
+	}
+
+	feeds := make([]oracleFeed, len(pusherCfg.Feeds))
+	for i, feed := range pusherCfg.Feeds {
+		token, currency := strings.ToLower(feed.Token), strings.ToLower(feed.Currency)
+		if currency == "" {
+			currency = cfg.Oracle.DefaultCurrency
+		}
+		if !quotes.IsTokenSupported(token) || !quotes.IsCurrencySupported(currency) {
+			return nil, fmt.Errorf("unsupported oracle feed %s/%s", feed.Token, feed.Currency)
+		}
+		feeds[i] = oracleFeed{token: token, currency: quotes.Currency(currency)}
+	}
+
+	return &OraclePusher{
+		config:       cfg,
+		signer:       signer,
+		node:         mavryk.NewClient(pusherCfg.NodeURL, pusherCfg.Chain, cfg.GetPusherTimeout()),
+		attestations: get_attestation.New(quoteRepo, signer, cfg.Oracle.Decimals, cfg.GetAverageMaxGap()),
+		pushes:       pushes,
+		policy: oracle.PushPolicy{
+			DeviationPercent: cfg.GetPusherDeviationPercent(),
+			Heartbeat:        cfg.GetPusherHeartbeat(),
+		},
+		feeds:    feeds,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Run checks the feeds every interval until ctx is cancelled or Stop is called
+func (p *OraclePusher) Run(ctx context.Context) error {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.config.GetPusherInterval())
+	defer ticker.Stop()
+
+	log.Printf("Oracle pusher started for %s with %d feeds", p.config.Oracle.Pusher.Contract, len(p.feeds))
+	for {
+		p.push(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-p.stopping:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Stop waits for an in-flight push to be recorded
+func (p *OraclePusher) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stopping)
+	})
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// push submits every due feed. It does not use ctx for the submission itself, so a shutdown
+// never leaves an injected operation unrecorded.
+func (p *OraclePusher) push(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+	pushCtx, cancel := context.WithTimeout(context.Background(), oraclePushTimeout)
+	defer cancel()
+
+	contract := p.config.Oracle.Pusher.Contract
+	if err := p.confirm(pushCtx); err != nil {
+		log.Printf("Oracle pusher: failed to confirm pending pushes to %s: %v", contract, err)
+		return
+	}
+
+	now := time.Now()
+	var due []oracle.Push
+	var attestations []oracle.Attestation
+	for _, feed := range p.feeds {
+		attestation, err := p.attestations.Execute(pushCtx, feed.token, feed.currency)
+		if err != nil {
+			log.Printf("Oracle pusher: no attestation for %s/%s: %v", feed.token, feed.currency, err)
+			continue
+		}
+		last, err := p.pushes.GetLast(pushCtx, contract, feed.token, feed.currency)
+		if err != nil {
+			log.Printf("Oracle pusher: %v", err)
+			return
+		}
+		if last != nil && last.Status == oracle.PushPending {
+			continue
+		}
+
+		reason, ok := p.policy.Decide(last, attestation.Payload, now)
+		if !ok {
+			continue
+		}
+		due = append(due, oracle.Push{Contract: contract, Payload: attestation.Payload, Reason: reason})
+		attestations = append(attestations, attestation)
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	operationHash, level, err := p.submit(pushCtx, attestations)
+	if err != nil {
+		log.Printf("Oracle pusher: failed to submit %d updates to %s: %v", len(due), contract, err)
+		return
+	}
+
+	for _, push := range due {
+		push.OperationHash = operationHash
+		push.PushedAt = time.Now()
+		push.Status = oracle.PushPending
+		push.InjectedLevel = level
+		if err := p.pushes.Save(pushCtx, push); err != nil {
+			log.Printf("Oracle pusher: %v", err)
+		}
+		log.Printf("Oracle pusher: injected %s/%s = %s (%s) in %s",
+			push.Payload.Token, push.Payload.Currency, push.Payload.Price, push.Reason, operationHash)
+	}
+}
+
+// confirm looks for the operations of pending pushes in the blocks baked since they were injected.
+// Pushes whose operation was not included within operationTTL levels are marked failed, so their
+// feeds are pushed again.
+func (p *OraclePusher) confirm(ctx context.Context) error {
+	pending, err := p.pushes.GetPending(ctx, p.config.Oracle.Pusher.Contract)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+	head, err := p.node.HeadLevel(ctx)
+	if err != nil {
+		return err
+	}
+
+	injected := make(map[string]int64, len(pending)) // operation hash to injection level
+	from, to := head+1, int64(0)
+	for _, push := range pending {
+		injected[push.OperationHash] = push.InjectedLevel
+		from = min(from, push.InjectedLevel+1)
+		to = max(to, push.InjectedLevel+operationTTL)
+	}
+
+	for level := from; level <= min(head, to) && len(injected) > 0; level++ {
+		hashes, err := p.node.ManagerOperationHashes(ctx, level)
+		if err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			if _, ok := injected[hash]; !ok {
+				continue
+			}
+			if err := p.pushes.SetStatus(ctx, hash, oracle.PushIncluded, level); err != nil {
+				return err
+			}
+			log.Printf("Oracle pusher: %s included at level %d", hash, level)
+			delete(injected, hash)
+		}
+	}
+
+	for hash, level := range injected {
+		if head-level <= operationTTL {
+			continue
+		}
+		if err := p.pushes.SetStatus(ctx, hash, oracle.PushFailed, 0); err != nil {
+			return err
+		}
+		log.Printf("Oracle pusher: %s was not included within %d levels, its feeds will be pushed again", hash, operationTTL)
+	}
+	return nil
+}
+
+// submit forges, signs and injects one contract call per attestation, and returns the operation hash
+// with the head level at injection
+func (p *OraclePusher) submit(ctx context.Context, attestations []oracle.Attestation) (string, int64, error) {
+	pusherCfg := p.config.Oracle.Pusher
+	source := p.signer.Key().PublicKeyHash
+
+	if err := p.node.CheckRevealed(ctx, source); err != nil {
+		return "", 0, fmt.Errorf("source %s: %w", source, err)
+	}
+	branch, err := p.node.Branch(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	counter, err := p.node.Counter(ctx, source)
+	if err != nil {
+		return "", 0, err
+	}
+
+	transactions := make([]signing.Transaction, len(attestations))
+	for i, attestation := range attestations {
+		parameters, err := signing.AttestationParameter(attestation)
+		if err != nil {
+			return "", 0, err
+		}
+		transactions[i] = signing.Transaction{
+			Counter:      counter + int64(i) + 1,
+			Fee:          pusherCfg.FeeMutez,
+			GasLimit:     pusherCfg.GasLimit,
+			StorageLimit: pusherCfg.StorageLimit,
+			Destination:  pusherCfg.Contract,
+			Entrypoint:   pusherCfg.Entrypoint,
+			Parameters:   parameters,
+		}
+	}
+
+	forged, err := p.signer.ForgeOperation(branch, transactions)
+	if err != nil {
+		return "", 0, err
+	}
+	if pusherCfg.FeeMutez == 0 {
+		// The fee depends on the operation size, which barely changes once fees are set
+		size := int64(signing.OperationSize(forged)) + int64(len(transactions))*4
+		for i := range transactions {
+			transactions[i].Fee = minimalFeeMutez + (transactions[i].GasLimit+gasUnitsPerMutez-1)/gasUnitsPerMutez +
+				(size+int64(len(transactions))-1)/int64(len(transactions)) + feeMarginMutez
+		}
+		if forged, err = p.signer.ForgeOperation(branch, transactions); err != nil {
+			return "", 0, err
+		}
+	}
+
+	signed, err := p.signer.SignOperation(ctx, forged)
+	if err != nil {
+		return "", 0, err
+	}
+	level, err := p.node.HeadLevel(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	operationHash, err := p.node.Inject(ctx, signed)
+	if err != nil {
+		return "", 0, err
+	}
+	if expected := signing.OperationHash(signed); operationHash != expected {
+		log.Printf("Oracle pusher: node reported operation %s, expected %s", operationHash, expected)
+	}
+	return operationHash, level, nil
+}
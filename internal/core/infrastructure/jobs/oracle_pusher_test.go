@@ -0,0 +1,160 @@
+package jobs
+
+import (
+	"context"
+	"net/http/httptest"
+	"quotes/internal/config"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/interactions/mavryk/mavryktest"
+	"quotes/internal/core/infrastructure/signing"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testContract = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+
+// memoryQuotes serves one quote as the last quote of every token
+type memoryQuotes struct {
+	mu    sync.Mutex
+	quote quotes.Quote
+}
+
+func (m *memoryQuotes) set(quote quotes.Quote) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quote = quote
+}
+
+func (m *memoryQuotes) GetLastQuote(ctx context.Context, tokenName string) (quotes.Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.quote, nil
+}
+
+func (m *memoryQuotes) GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error) {
+	return nil, nil
+}
+
+// memoryPushes is an in-memory oraclePushes
+type memoryPushes struct {
+	pushes []oracle.Push
+}
+
+func (m *memoryPushes) Save(ctx context.Context, push oracle.Push) error {
+	m.pushes = append(m.pushes, push)
+	return nil
+}
+
+func (m *memoryPushes) GetLast(ctx context.Context, contract, tokenName string, currency quotes.Currency) (*oracle.Push, error) {
+	for i := len(m.pushes) - 1; i >= 0; i-- {
+		push := m.pushes[i]
+		if push.Contract == contract && push.Payload.Token == tokenName && push.Payload.Currency == currency && push.Status != oracle.PushFailed {
+			return &push, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryPushes) GetPending(ctx context.Context, contract string) ([]oracle.Push, error) {
+	var pending []oracle.Push
+	for _, push := range m.pushes {
+		if push.Contract == contract && push.Status == oracle.PushPending {
+			pending = append(pending, push)
+		}
+	}
+	return pending, nil
+}
+
+func (m *memoryPushes) SetStatus(ctx context.Context, operationHash string, status oracle.PushStatus, level int64) error {
+	for i := range m.pushes {
+		if m.pushes[i].OperationHash == operationHash && m.pushes[i].Status == oracle.PushPending {
+			m.pushes[i].Status = status
+			m.pushes[i].IncludedLevel = level
+		}
+	}
+	return nil
+}
+
+func (m *memoryPushes) count(status oracle.PushStatus) int {
+	n := 0
+	for _, push := range m.pushes {
+		if push.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func TestOraclePusher(t *testing.T) {
+	node := mavryktest.NewNode()
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	ctx := context.Background()
+	backend, err := signing.NewLocalSigner("edsk3gUfUPyBSfrS9CCgmCiQsTCHGkviBDusMxDJstFtojtc1zcpsh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signing.NewSigner(ctx, backend, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deviation := 1.0
+	cfg := &config.Config{}
+	cfg.Oracle.Decimals = 8
+	cfg.Oracle.Pusher = config.OraclePusherConfig{
+		NodeURL:          server.URL,
+		Chain:            "main",
+		Contract:         testContract,
+		Entrypoint:       "update",
+		Feeds:            []config.OracleFeedConfig{{Token: "mvrk", Currency: "usd"}, {Token: "mvrk", Currency: "eur"}},
+		DeviationPercent: &deviation,
+		HeartbeatSeconds: 3600,
+		GasLimit:         20000,
+		StorageLimit:     100,
+		TimeoutSeconds:   5,
+	}
+
+	quoteRepo := &memoryQuotes{}
+	pushes := &memoryPushes{}
+	pusher, err := newOraclePusher(cfg, signer, quoteRepo, pushes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	steps := []struct {
+		name         string
+		quote        quotes.Quote
+		wantCounter  int64
+		wantPending  int
+		wantIncluded int
+	}{
+		// Both feeds go in one operation of two transactions, pending until found in a block
+		{"initial", quotes.Quote{Timestamp: start, USD: 0.1, EUR: 0.09}, 2, 2, 0},
+		// The operation was baked: both pushes are included and nothing moved enough to push again
+		{"confirmed", quotes.Quote{Timestamp: start.Add(time.Minute), USD: 0.1005, EUR: 0.09}, 2, 0, 2},
+		// Only the USD price deviates; the node rejects the operation unless its counter follows the last one
+		{"deviation", quotes.Quote{Timestamp: start.Add(2 * time.Minute), USD: 0.102, EUR: 0.09}, 3, 1, 2},
+		{"confirmed again", quotes.Quote{Timestamp: start.Add(3 * time.Minute), USD: 0.102, EUR: 0.09}, 3, 0, 3},
+	}
+	for _, step := range steps {
+		quoteRepo.set(step.quote)
+		pusher.push(ctx)
+
+		if counter := node.Counter(); counter != step.wantCounter {
+			t.Errorf("%s: node counter = %d, want %d", step.name, counter, step.wantCounter)
+		}
+		if pending, included := pushes.count(oracle.PushPending), pushes.count(oracle.PushIncluded); pending != step.wantPending || included != step.wantIncluded {
+			t.Errorf("%s: %d pending and %d included pushes, want %d and %d", step.name, pending, included, step.wantPending, step.wantIncluded)
+		}
+	}
+
+	last, _ := pushes.GetLast(ctx, testContract, "mvrk", quotes.CurrencyUSD)
+	if last == nil || last.Reason != oracle.PushDeviation || last.IncludedLevel != node.HeadLevel() {
+		t.Errorf("last USD push = %+v, want a deviation included at level %d", last, node.HeadLevel())
+	}
+}
@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"quotes/internal/config"
+	"quotes/internal/core/domain/proofs"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/storage/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const commitDayTimeout = time.Minute
+
+// PriceCommitter stores the Merkle root of the quotes of every token for each UTC day, once the day
+// is over and its quotes are final. Days missed while the service was down are committed on the next run.
+type PriceCommitter struct {
+	config      *config.Config
+	quotes      *repositories.QuoteRepository
+	commitments *repositories.PriceCommitmentRepository
+	startDay    time.Time // first day committed for a token without commitments (zero = yesterday)
+
+	stopOnce sync.Once
+	stopping chan struct{} // closed by Stop to cancel committing
+	done     chan struct{} // closed by Run once it has returned
+}
+
+func NewPriceCommitter(cfg *config.Config, db *gorm.DB) (*PriceCommitter, error) {
+	var startDay time.Time
+	if value := cfg.Proofs.StartDate; value != "" {
+		date, err := config.ParseDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proofs.start_date %q", value)
+		}
+		startDay = proofs.DayStart(date)
+	}
+
+	return &PriceCommitter{
+		config:      cfg,
+		quotes:      repositories.NewQuoteRepository(db),
+		commitments: repositories.NewPriceCommitmentRepository(db),
+		startDay:    startDay,
+		stopping:    make(chan struct{}),
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// Run commits the days that are due every check interval until ctx is cancelled or Stop is called
+func (p *PriceCommitter) Run(ctx context.Context) error {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.config.GetProofsCheckInterval())
+	defer ticker.Stop()
+
+	log.Printf("Price committer started")
+	for {
+		p.commitDue(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-p.stopping:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Stop waits for the day being committed
+func (p *PriceCommitter) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stopping)
+	})
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// commitDue commits, for every token, the days after its last commitment whose quotes are final
+func (p *PriceCommitter) commitDue(ctx context.Context) {
+	// The last day whose quotes can no longer be rewritten by the collector
+	lastDay := proofs.DayStart(time.Now().Add(-p.config.GetFinalityHorizon())).Add(-proofs.Day)
+
+	tokens := quotes.GetSupportedTokenNames()
+	sort.Strings(tokens)
+	for _, token := range tokens {
+		last, ok, err := p.commitments.GetLastDay(ctx, token)
+		if err != nil {
+			log.Printf("Price committer: %v", err)
+			return
+		}
+
+		day := lastDay
+		switch {
+		case ok:
+			day = last.Add(proofs.Day)
+		case !p.startDay.IsZero():
+			day = p.startDay
+		}
+
+		for ; !day.After(lastDay); day = day.Add(proofs.Day) {
+			select {
+			case <-p.stopping:
+				return
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if err := p.commit(token, day); err != nil {
+				log.Printf("Price committer: %v", err)
+				break
+			}
+		}
+	}
+}
+
+func (p *PriceCommitter) commit(token string, day time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), commitDayTimeout)
+	defer cancel()
+
+	quotesList, err := p.quotes.GetQuotes(ctx, day, day.Add(proofs.Day), 0, token)
+	if err != nil {
+		return err
+	}
+	dayQuotes := proofs.DayQuotes(day, quotesList)
+
+	commitment := proofs.Commitment{
+		Token:     token,
+		Day:       day,
+		Root:      proofs.NewDayTree(token, dayQuotes).Root(),
+		LeafCount: len(dayQuotes),
+	}
+	if err := p.commitments.Save(ctx, commitment); err != nil {
+		return err
+	}
+
+	log.Printf("Committed %d quotes of %s for %s: root %s", commitment.LeafCount, token, day.Format(time.DateOnly), commitment.Root)
+	return nil
+}
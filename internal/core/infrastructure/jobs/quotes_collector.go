@@ -17,32 +17,76 @@ type tokenCollector struct {
 	token  quotes.Token
 	ticker *time.Ticker
 	client *coingecko.Client
-	done   chan bool
+}
+
+// QuotesListener is notified after new quotes have been persisted for a token
+type QuotesListener interface {
+	OnQuotesSaved(tokenName string, quotesList []quotes.Quote)
 }
 
 type QuotesCollector struct {
 	config     *config.Config
 	repository *repositories.QuoteRepository
+	listeners  []QuotesListener
 	collectors map[string]*tokenCollector
-	done       chan bool
+
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+	stopping chan struct{} // closed by Stop to cancel collection
+	done     chan struct{} // closed by Run once every collector has returned
 }
 
-func NewQuotesCollector(cfg *config.Config, db *gorm.DB) *QuotesCollector {
+func NewQuotesCollector(cfg *config.Config, db *gorm.DB, listeners ...QuotesListener) *QuotesCollector {
 	return &QuotesCollector{
 		config:     cfg,
 		repository: repositories.NewQuoteRepository(db),
+		listeners:  listeners,
 		collectors: make(map[string]*tokenCollector),
-		done:       make(chan bool),
+		stopping:   make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Run performs the backfill, then collects quotes periodically until ctx is cancelled or Stop is called
+func (c *QuotesCollector) Run(ctx context.Context) error {
+	defer close(c.done)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stopping:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	c.start(ctx)
+	c.wg.Wait()
+	return nil
+}
+
+// Stop cancels collection and waits for in-flight requests and saves to finish
+func (c *QuotesCollector) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() {
+		close(c.stopping)
+	})
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
-func (c *QuotesCollector) Start(ctx context.Context) {
+func (c *QuotesCollector) start(ctx context.Context) {
 	// Run backfill for tokens that have it enabled (either globally or token-specific)
 	supportedTokens := quotes.GetSupportedTokens()
 	hasBackfill := false
 	for _, token := range supportedTokens {
 		tokenName := string(token)
-		if c.config.IsTokenBackfillEnabled(tokenName) {
+		if !quotes.IsBasket(tokenName) && c.config.IsTokenBackfillEnabled(tokenName) {
 			hasBackfill = true
 			break
 		}
@@ -56,6 +100,9 @@ func (c *QuotesCollector) Start(ctx context.Context) {
 			log.Println("Backfill completed successfully")
 		}
 	}
+	if ctx.Err() != nil {
+		return
+	}
 
 	if !c.config.Job.Enabled {
 		log.Println("Quotes collection job is disabled - skipping periodic collection")
@@ -64,6 +111,9 @@ func (c *QuotesCollector) Start(ctx context.Context) {
 	for _, token := range supportedTokens {
 		tokenName := string(token)
 
+		if quotes.IsBasket(tokenName) {
+			continue // computed by the basket calculator
+		}
 		if !c.config.IsTokenEnabled(tokenName) {
 			log.Printf("Token %s is disabled - skipping", tokenName)
 			continue
@@ -77,23 +127,23 @@ func (c *QuotesCollector) Start(ctx context.Context) {
 
 		client := coingecko.NewClient(c.config.CoinGecko.BaseURL, c.config.CoinGecko.APIKey, timeout)
 
-		ticker := time.NewTicker(interval)
-		done := make(chan bool)
-
 		collector := &tokenCollector{
 			token:  token,
-			ticker: ticker,
+			ticker: time.NewTicker(interval),
 			client: client,
-			done:   done,
 		}
 
 		c.collectors[tokenName] = collector
 
+		c.wg.Add(1)
 		go c.startTokenCollector(ctx, collector, tokenCfg)
 	}
 }
 
 func (c *QuotesCollector) startTokenCollector(ctx context.Context, collector *tokenCollector, tokenCfg config.TokenConfig) {
+	defer c.wg.Done()
+	defer collector.ticker.Stop()
+
 	tokenName := string(collector.token)
 
 	c.collectQuotesForToken(ctx, collector.token, collector.client, tokenCfg)
@@ -102,27 +152,13 @@ func (c *QuotesCollector) startTokenCollector(ctx context.Context, collector *to
 		select {
 		case <-collector.ticker.C:
 			c.collectQuotesForToken(ctx, collector.token, collector.client, tokenCfg)
-		case <-collector.done:
-			log.Printf("Token collector for %s stopped", tokenName)
-			return
 		case <-ctx.Done():
-			log.Printf("Token collector for %s stopped due to context cancellation", tokenName)
+			log.Printf("Token collector for %s stopped", tokenName)
 			return
 		}
 	}
 }
 
-func (c *QuotesCollector) Stop() {
-	for tokenName, collector := range c.collectors {
-		if collector.ticker != nil {
-			collector.ticker.Stop()
-		}
-		collector.done <- true
-		log.Printf("Stopped collector for token: %s", tokenName)
-	}
-	c.done <- true
-}
-
 func (c *QuotesCollector) collectQuotesForToken(ctx context.Context, token quotes.Token, client *coingecko.Client, tokenCfg config.TokenConfig) {
 	tokenName := string(token)
 	log.Printf("Starting quotes collection for token: %s", tokenName)
@@ -185,10 +221,17 @@ func (c *QuotesCollector) collectQuotesForToken(ctx context.Context, token quote
 		log.Printf("Error saving quotes for %s: %v", tokenName, err)
 		return
 	}
+	c.notifySaved(tokenName, filteredQuotes)
 
 	log.Printf("Successfully collected and saved %d new quotes for %s", len(filteredQuotes), tokenName)
 }
 
+func (c *QuotesCollector) notifySaved(tokenName string, quotesList []quotes.Quote) {
+	for _, listener := range c.listeners {
+		listener.OnQuotesSaved(tokenName, quotesList)
+	}
+}
+
 func (c *QuotesCollector) filterNewQuotes(ctx context.Context, quotesList []quotes.Quote, tokenName string) []quotes.Quote {
 	if len(quotesList) == 0 {
 		return quotesList
@@ -224,6 +267,9 @@ func (c *QuotesCollector) runBackfill(ctx context.Context) error {
 	var wg sync.WaitGroup
 	for _, token := range supportedTokens {
 		tokenName := string(token)
+		if quotes.IsBasket(tokenName) {
+			continue
+		}
 
 		// Check if backfill is enabled for this token
 		if !c.config.IsTokenBackfillEnabled(tokenName) {
@@ -238,13 +284,8 @@ func (c *QuotesCollector) runBackfill(ctx context.Context) error {
 			continue
 		}
 
-		var start time.Time
-		// Try RFC3339 first, then fallback to date-only
-		if t, err := time.Parse(time.RFC3339, startFrom); err == nil {
-			start = t.UTC()
-		} else if t, err2 := time.Parse("2006-01-02", startFrom); err2 == nil {
-			start = t.UTC()
-		} else {
+		start, err := config.ParseDate(startFrom)
+		if err != nil {
 			log.Printf("Invalid backfill start date format for token %s: %s", tokenName, startFrom)
 			continue
 		}
@@ -315,6 +356,10 @@ func (c *QuotesCollector) runBackfillForToken(ctx context.Context, token quotes.
 	client := coingecko.NewClient(c.config.CoinGecko.BaseURL, c.config.CoinGecko.APIKey, timeout)
 
 	for from.Before(now) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		to := from.Add(chunk)
 		if to.After(now) {
 			to = now
@@ -354,6 +399,7 @@ func (c *QuotesCollector) runBackfillForToken(ctx context.Context, token quotes.
 					log.Printf("Backfill save error for %s: %v", tokenName, err)
 				} else {
 					log.Printf("Backfill saved %d quotes for %s", len(filtered), tokenName)
+					c.notifySaved(tokenName, filtered)
 				}
 			}
 		}
@@ -373,7 +419,11 @@ func (c *QuotesCollector) runBackfillForToken(ctx context.Context, token quotes.
 				sleepMs = 1100
 			}
 		}
-		time.Sleep(time.Duration(sleepMs) * time.Millisecond)
+		select {
+		case <-time.After(time.Duration(sleepMs) * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
 	return nil
@@ -0,0 +1,151 @@
+package pubsub
+
+import (
+	"log"
+	"quotes/internal/core/domain/quotes"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Event is a quote that has just been persisted for a token
+type Event struct {
+	TokenName string
+	Quote     quotes.Quote
+}
+
+// Hub fans out quotes saved by the collector to live subscribers (stream endpoints).
+// Publishing never blocks: a subscriber whose buffer is full is dropped and its
+// channel closed, so a slow client can't hold up the collector.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives events for a set of tokens
+type Subscription struct {
+	hub     *Hub
+	events  chan Event
+	tokens  map[string]bool // guarded by hub.mu; empty = no tokens
+	dropped atomic.Bool
+	closed  bool // guarded by hub.mu
+}
+
+// Subscribe registers a subscriber for the given tokens with a buffer of bufferSize events
+func (h *Hub) Subscribe(tokens []string, bufferSize int) *Subscription {
+	s := &Subscription{
+		hub:    h,
+		events: make(chan Event, bufferSize),
+		tokens: make(map[string]bool),
+	}
+	for _, token := range tokens {
+		s.tokens[strings.ToLower(token)] = true
+	}
+
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+
+	return s
+}
+
+// OnQuotesSaved publishes the saved quotes in timestamp order to every subscriber of the token
+func (h *Hub) OnQuotesSaved(tokenName string, quotesList []quotes.Quote) {
+	if len(quotesList) == 0 {
+		return
+	}
+
+	tokenName = strings.ToLower(tokenName)
+
+	sorted := make([]quotes.Quote, len(quotesList))
+	copy(sorted, quotesList)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var slow []*Subscription
+
+	h.mu.RLock()
+	for s := range h.subscribers {
+		if s.tokens[tokenName] && !s.deliver(tokenName, sorted) {
+			slow = append(slow, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, s := range slow {
+		log.Printf("Dropping slow stream subscriber (buffer of %d events is full)", cap(s.events))
+		s.dropped.Store(true)
+		s.Close()
+	}
+}
+
+// deliver queues the quotes without blocking, returning false when the buffer is full. Caller holds hub.mu.
+func (s *Subscription) deliver(tokenName string, quotesList []quotes.Quote) bool {
+	for _, quote := range quotesList {
+		select {
+		case s.events <- Event{TokenName: tokenName, Quote: quote}:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Events returns the channel of published events. It is closed when the subscription
+// is closed or dropped.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped reports whether the hub closed the subscription because its buffer was full
+func (s *Subscription) Dropped() bool {
+	return s.dropped.Load()
+}
+
+// AddTokens subscribes to more tokens
+func (s *Subscription) AddTokens(tokens []string) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	for _, token := range tokens {
+		s.tokens[strings.ToLower(token)] = true
+	}
+}
+
+// RemoveTokens unsubscribes from tokens
+func (s *Subscription) RemoveTokens(tokens []string) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	for _, token := range tokens {
+		delete(s.tokens, strings.ToLower(token))
+	}
+}
+
+// Tokens returns the subscribed tokens, sorted
+func (s *Subscription) Tokens() []string {
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+	tokens := make([]string, 0, len(s.tokens))
+	for token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// Close unregisters the subscription and closes its channel. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(s.hub.subscribers, s)
+	close(s.events)
+}
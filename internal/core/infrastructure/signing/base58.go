@@ -0,0 +1,92 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58check prefixes used by Tezos and Mavryk tooling
+var (
+	prefixEd25519SecretKey     = []byte{43, 246, 78, 7}       // edsk (64 bytes: seed and public key)
+	prefixEd25519Seed          = []byte{13, 15, 58, 7}        // edsk (32-byte seed)
+	prefixEd25519PublicKey     = []byte{13, 15, 37, 217}      // edpk
+	prefixEd25519Signature     = []byte{9, 245, 205, 134, 18} // edsig
+	prefixSecp256k1SecretKey   = []byte{17, 162, 224, 201}    // spsk
+	prefixSecp256k1PublicKey   = []byte{3, 254, 226, 86}      // sppk
+	prefixSecp256k1Signature   = []byte{13, 115, 101, 19, 63} // spsig1
+	prefixP256SecretKey        = []byte{16, 81, 238, 189}     // p2sk
+	prefixP256PublicKey        = []byte{3, 178, 139, 127}     // p2pk
+	prefixP256Signature        = []byte{54, 240, 44, 52}      // p2sig
+	prefixEd25519PublicKeyHash = []byte{5, 186, 196}          // mv1
+	prefixSecp256k1KeyHash     = []byte{5, 186, 199}          // mv2
+	prefixP256PublicKeyHash    = []byte{5, 186, 201}          // mv3
+)
+
+var errInvalidBase58 = errors.New("invalid base58check encoding")
+
+// base58CheckEncode encodes prefix+payload with a double SHA-256 checksum
+func base58CheckEncode(prefix, payload []byte) string {
+	data := append(append([]byte{}, prefix...), payload...)
+	checksum := doubleSHA256(data)
+	data = append(data, checksum[:4]...)
+
+	var encoded []byte
+	x := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58CheckDecode decodes a base58check string and strips the expected prefix
+func base58CheckDecode(value string, prefix []byte) ([]byte, error) {
+	x := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range value {
+		digit := strings.IndexRune(base58Alphabet, r)
+		if digit < 0 {
+			return nil, errInvalidBase58
+		}
+		x.Mul(x, radix)
+		x.Add(x, big.NewInt(int64(digit)))
+	}
+	data := x.Bytes()
+	for _, r := range value {
+		if r != rune(base58Alphabet[0]) {
+			break
+		}
+		data = append([]byte{0}, data...)
+	}
+
+	if len(data) < len(prefix)+4 {
+		return nil, errInvalidBase58
+	}
+	payload, checksum := data[:len(data)-4], data[len(data)-4:]
+	expected := doubleSHA256(payload)
+	if !bytes.Equal(checksum, expected[:4]) || !bytes.HasPrefix(payload, prefix) {
+		return nil, errInvalidBase58
+	}
+	return payload[len(prefix):], nil
+}
+
+func doubleSHA256(data []byte) [32]byte {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
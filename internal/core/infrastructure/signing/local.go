@@ -0,0 +1,133 @@
+package signing
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// LocalSigner signs with a secret key held in memory. It is meant for development:
+// production keys belong in a remote signer.
+type LocalSigner struct {
+	publicKey       string
+	sign            func(digest []byte) ([]byte, error)
+	signaturePrefix []byte
+}
+
+// NewLocalSigner loads an unencrypted base58check secret key: edsk..., spsk... or p2sk...
+func NewLocalSigner(secretKey string) (*LocalSigner, error) {
+	secretKey = strings.TrimSpace(secretKey)
+	switch {
+	case strings.HasPrefix(secretKey, "edsk"):
+		return newEd25519Signer(secretKey)
+	case strings.HasPrefix(secretKey, "spsk"):
+		return newSecp256k1Signer(secretKey)
+	case strings.HasPrefix(secretKey, "p2sk"):
+		return newP256Signer(secretKey)
+	case strings.HasPrefix(secretKey, "edesk"), strings.HasPrefix(secretKey, "spesk"), strings.HasPrefix(secretKey, "p2esk"):
+		return nil, errors.New("encrypted secret keys are not supported")
+	default:
+		return nil, errors.New("unknown secret key format: expected edsk..., spsk... or p2sk...")
+	}
+}
+
+// PublicKey implements Backend
+func (s *LocalSigner) PublicKey(ctx context.Context) (string, error) {
+	return s.publicKey, nil
+}
+
+// Sign implements Backend
+func (s *LocalSigner) Sign(ctx context.Context, message []byte) (string, error) {
+	digest := blake2b.Sum256(message)
+	signature, err := s.sign(digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %w", err)
+	}
+	return base58CheckEncode(s.signaturePrefix, signature), nil
+}
+
+func newEd25519Signer(secretKey string) (*LocalSigner, error) {
+	var private ed25519.PrivateKey
+	if raw, err := base58CheckDecode(secretKey, prefixEd25519SecretKey); err == nil && len(raw) == ed25519.PrivateKeySize {
+		private = ed25519.NewKeyFromSeed(raw[:ed25519.SeedSize])
+	} else if raw, err := base58CheckDecode(secretKey, prefixEd25519Seed); err == nil && len(raw) == ed25519.SeedSize {
+		private = ed25519.NewKeyFromSeed(raw)
+	} else {
+		return nil, errors.New("invalid ed25519 secret key")
+	}
+
+	public := private.Public().(ed25519.PublicKey)
+	return &LocalSigner{
+		publicKey: base58CheckEncode(prefixEd25519PublicKey, public),
+		sign: func(digest []byte) ([]byte, error) {
+			return ed25519.Sign(private, digest), nil
+		},
+		signaturePrefix: prefixEd25519Signature,
+	}, nil
+}
+
+func newSecp256k1Signer(secretKey string) (*LocalSigner, error) {
+	raw, err := base58CheckDecode(secretKey, prefixSecp256k1SecretKey)
+	if err != nil || len(raw) != 32 {
+		return nil, errInvalidSecp256k1Key
+	}
+	private, err := parseSecp256k1Secret(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalSigner{
+		publicKey: base58CheckEncode(prefixSecp256k1PublicKey, private.PubKey().SerializeCompressed()),
+		sign: func(digest []byte) ([]byte, error) {
+			return secp256k1Sign(private, digest), nil
+		},
+		signaturePrefix: prefixSecp256k1Signature,
+	}, nil
+}
+
+func newP256Signer(secretKey string) (*LocalSigner, error) {
+	raw, err := base58CheckDecode(secretKey, prefixP256SecretKey)
+	if err != nil || len(raw) != 32 {
+		return nil, errors.New("invalid p256 secret key")
+	}
+	ecdhKey, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256 secret key: %w", err)
+	}
+
+	// Uncompressed point: 0x04 || x || y
+	uncompressed := ecdhKey.PublicKey().Bytes()
+	x, y := new(big.Int).SetBytes(uncompressed[1:33]), new(big.Int).SetBytes(uncompressed[33:])
+	private := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		D:         new(big.Int).SetBytes(raw),
+	}
+
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 | byte(y.Bit(0))
+	copy(compressed[1:], uncompressed[1:33])
+
+	return &LocalSigner{
+		publicKey: base58CheckEncode(prefixP256PublicKey, compressed),
+		sign: func(digest []byte) ([]byte, error) {
+			r, s, err := ecdsa.Sign(rand.Reader, private, digest)
+			if err != nil {
+				return nil, err
+			}
+			signature := make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+			return signature, nil
+		},
+		signaturePrefix: prefixP256Signature,
+	}, nil
+}
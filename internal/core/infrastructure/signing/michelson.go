@@ -0,0 +1,77 @@
+package signing
+
+import (
+	"encoding/binary"
+	"math/big"
+	"quotes/internal/core/domain/oracle"
+)
+
+// Binary Micheline tags and primitives used by the payloads
+const (
+	packPrefix        = 0x05
+	tagInt            = 0x00
+	tagString         = 0x01
+	tagPrimTwoArgs    = 0x07
+	primPair          = 0x07
+	zarithSignBit     = 0x40
+	zarithContinueBit = 0x80
+)
+
+// Pack encodes the payload as PACK would: Pair timestamp (Pair token (Pair currency price))
+func Pack(payload oracle.Payload) []byte {
+	packed := []byte{packPrefix}
+	packed = appendPair(packed)
+	packed = appendInt(packed, big.NewInt(payload.Timestamp.Unix()))
+	packed = appendPair(packed)
+	packed = appendString(packed, payload.Token)
+	packed = appendPair(packed)
+	packed = appendString(packed, string(payload.Currency))
+	packed = appendInt(packed, payload.Price)
+	return packed
+}
+
+// PackCandle encodes a candle as a Harbinger signer packs it: Pair asset (Pair start (Pair end (Pair open ...)))
+func PackCandle(candle oracle.Candle) []byte {
+	packed := []byte{packPrefix}
+	packed = appendPair(packed)
+	packed = appendString(packed, candle.Asset)
+	packed = appendPair(packed)
+	packed = appendInt(packed, big.NewInt(candle.Start.Unix()))
+	packed = appendPair(packed)
+	packed = appendInt(packed, big.NewInt(candle.End.Unix()))
+	for _, value := range []*big.Int{candle.Open, candle.High, candle.Low} {
+		packed = appendPair(packed)
+		packed = appendInt(packed, value)
+	}
+	packed = appendPair(packed)
+	packed = appendInt(packed, candle.Close)
+	return appendInt(packed, candle.Volume)
+}
+
+func appendPair(b []byte) []byte {
+	return append(b, tagPrimTwoArgs, primPair)
+}
+
+func appendString(b []byte, value string) []byte {
+	b = append(b, tagString)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(value)))
+	return append(b, value...)
+}
+
+// appendInt appends a Micheline int: zarith encoding, 6 bits and the sign in the first byte, then 7 bits per byte
+func appendInt(b []byte, value *big.Int) []byte {
+	b = append(b, tagInt)
+
+	abs := new(big.Int).Abs(value)
+	first := byte(new(big.Int).And(abs, big.NewInt(0x3f)).Uint64())
+	if value.Sign() < 0 {
+		first |= zarithSignBit
+	}
+	abs.Rsh(abs, 6)
+	for abs.Sign() > 0 {
+		b = append(b, first|zarithContinueBit)
+		first = byte(new(big.Int).And(abs, big.NewInt(0x7f)).Uint64())
+		abs.Rsh(abs, 7)
+	}
+	return append(b, first)
+}
@@ -0,0 +1,257 @@
+package signing
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"quotes/internal/core/domain/oracle"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Binary encoding of manager operations (transaction tag since the Jakarta protocol)
+const (
+	operationWatermark    = 0x03 // generic operation magic byte, signed in front of the forged bytes
+	tagTransaction        = 0x6c
+	tagBytes              = 0x0a
+	tagContractOriginated = 0x01
+	tagEntrypointNamed    = 0xff
+	signatureSize         = 64
+)
+
+var (
+	prefixBlockHash        = []byte{1, 52}      // B...
+	prefixOperationHash    = []byte{5, 116}     // o...
+	prefixContractHash     = []byte{2, 90, 121} // KT1...
+	prefixGenericSignature = []byte{4, 130, 43} // sig...
+)
+
+// entrypointTags are the entrypoints with a reserved one-byte encoding
+var entrypointTags = map[string]byte{
+	"default":         0x00,
+	"root":            0x01,
+	"do":              0x02,
+	"set_delegate":    0x03,
+	"remove_delegate": 0x04,
+	"deposit":         0x05,
+}
+
+// Transaction is a call of a contract entrypoint by the signer account
+type Transaction struct {
+	Counter      int64 // source counter + 1 for the first transaction, then incremented
+	Fee          int64 // mutez
+	GasLimit     int64
+	StorageLimit int64
+	Destination  string // KT1...
+	Entrypoint   string
+	Parameters   []byte // binary Micheline of the argument (PACK without the 0x05 prefix)
+}
+
+// ForgeOperation encodes a batch of transactions sent by the signer account, anchored to the branch block (B...)
+func (s *Signer) ForgeOperation(branch string, transactions []Transaction) ([]byte, error) {
+	branchHash, err := base58CheckDecode(branch, prefixBlockHash)
+	if err != nil || len(branchHash) != 32 {
+		return nil, fmt.Errorf("invalid branch %q", branch)
+	}
+	source, err := encodeKeyHash(s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	forged := append([]byte{}, branchHash...)
+	for _, tx := range transactions {
+		if forged, err = appendTransaction(forged, source, tx); err != nil {
+			return nil, err
+		}
+	}
+	return forged, nil
+}
+
+func appendTransaction(forged, source []byte, tx Transaction) ([]byte, error) {
+	destination, err := base58CheckDecode(tx.Destination, prefixContractHash)
+	if err != nil || len(destination) != 20 {
+		return nil, fmt.Errorf("invalid contract address %q", tx.Destination)
+	}
+
+	forged = append(forged, tagTransaction)
+	forged = append(forged, source...)
+	forged = appendNat(forged, tx.Fee)
+	forged = appendNat(forged, tx.Counter)
+	forged = appendNat(forged, tx.GasLimit)
+	forged = appendNat(forged, tx.StorageLimit)
+	forged = appendNat(forged, 0) // amount
+	forged = append(forged, tagContractOriginated)
+	forged = append(forged, destination...)
+	forged = append(forged, 0x00) // padding
+
+	forged = append(forged, 0xff) // parameters present
+	if tag, ok := entrypointTags[tx.Entrypoint]; ok {
+		forged = append(forged, tag)
+	} else {
+		if len(tx.Entrypoint) == 0 || len(tx.Entrypoint) > 31 {
+			return nil, fmt.Errorf("invalid entrypoint %q", tx.Entrypoint)
+		}
+		forged = append(forged, tagEntrypointNamed, byte(len(tx.Entrypoint)))
+		forged = append(forged, tx.Entrypoint...)
+	}
+	forged = binary.BigEndian.AppendUint32(forged, uint32(len(tx.Parameters)))
+	return append(forged, tx.Parameters...), nil
+}
+
+// SignOperation signs forged operation bytes and returns them with the signature appended, ready for injection
+func (s *Signer) SignOperation(ctx context.Context, forged []byte) ([]byte, error) {
+	message := append([]byte{operationWatermark}, forged...)
+	signature, err := s.backend.Sign(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := decodeSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, forged...), raw...), nil
+}
+
+// OperationHash returns the hash of a signed operation, as the node reports it on injection
+func OperationHash(signed []byte) string {
+	hash := blake2b.Sum256(signed)
+	return base58CheckEncode(prefixOperationHash, hash[:])
+}
+
+// TransactionCounters decodes a signed operation forged by ForgeOperation and returns the counter of each
+// of its transactions, in order
+func TransactionCounters(signed []byte) ([]int64, error) {
+	if len(signed) < 32+signatureSize {
+		return nil, errors.New("operation too short")
+	}
+	contents := signed[32 : len(signed)-signatureSize]
+
+	var counters []int64
+	for len(contents) > 0 {
+		if contents[0] != tagTransaction || len(contents) < 22 {
+			return nil, fmt.Errorf("unsupported operation tag 0x%02x", contents[0])
+		}
+		contents = contents[22:] // tag and source
+
+		var fields [5]int64 // fee, counter, gas limit, storage limit, amount
+		for i := range fields {
+			value, n, err := readNat(contents)
+			if err != nil {
+				return nil, err
+			}
+			fields[i], contents = value, contents[n:]
+		}
+		counters = append(counters, fields[1])
+
+		if len(contents) < 23 {
+			return nil, errors.New("truncated transaction")
+		}
+		hasParameters := contents[22] == 0xff
+		contents = contents[23:] // destination, padding and parameters flag
+		if !hasParameters {
+			continue
+		}
+
+		entrypointSize := 1
+		if len(contents) > 1 && contents[0] == tagEntrypointNamed {
+			entrypointSize = 2 + int(contents[1])
+		}
+		if len(contents) < entrypointSize+4 {
+			return nil, errors.New("truncated transaction")
+		}
+		contents = contents[entrypointSize:]
+		size := binary.BigEndian.Uint32(contents)
+		if uint64(len(contents)-4) < uint64(size) {
+			return nil, errors.New("truncated parameters")
+		}
+		contents = contents[4+size:]
+	}
+	return counters, nil
+}
+
+// readNat reads a zarith natural number and returns it with its encoded length
+func readNat(b []byte) (int64, int, error) {
+	var value int64
+	for i, chunk := range b {
+		if i == 9 {
+			break
+		}
+		value |= int64(chunk&^zarithContinueBit) << (7 * i)
+		if chunk&zarithContinueBit == 0 {
+			return value, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("invalid natural number")
+}
+
+// OperationSize is the size of a signed operation, used to compute its fee
+func OperationSize(forged []byte) int {
+	return len(forged) + signatureSize
+}
+
+// AttestationParameter encodes the Michelson argument Pair <packed payload> <signature>,
+// of type pair bytes signature, for contracts that check attestations with CHECK_SIGNATURE
+func AttestationParameter(attestation oracle.Attestation) ([]byte, error) {
+	signature, err := decodeSignature(attestation.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameter []byte
+	parameter = appendPair(parameter)
+	parameter = appendBytes(parameter, attestation.Packed)
+	return appendBytes(parameter, signature), nil
+}
+
+func appendBytes(b []byte, value []byte) []byte {
+	b = append(b, tagBytes)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(value)))
+	return append(b, value...)
+}
+
+// appendNat appends a zarith natural number: 7 bits per byte, least significant first
+func appendNat(b []byte, value int64) []byte {
+	n := big.NewInt(value)
+	for {
+		chunk := byte(new(big.Int).And(n, big.NewInt(0x7f)).Uint64())
+		n.Rsh(n, 7)
+		if n.Sign() == 0 {
+			return append(b, chunk)
+		}
+		b = append(b, chunk|zarithContinueBit)
+	}
+}
+
+// encodeKeyHash encodes an implicit account: curve tag and 20-byte key hash
+func encodeKeyHash(key oracle.Key) ([]byte, error) {
+	for tag, format := range []struct {
+		algorithm string
+		prefix    []byte
+	}{
+		{AlgorithmEd25519, prefixEd25519PublicKeyHash},
+		{AlgorithmSecp256k1, prefixSecp256k1KeyHash},
+		{AlgorithmP256, prefixP256PublicKeyHash},
+	} {
+		if key.Algorithm != format.algorithm {
+			continue
+		}
+		hash, err := base58CheckDecode(key.PublicKeyHash, format.prefix)
+		if err != nil || len(hash) != 20 {
+			return nil, fmt.Errorf("invalid public key hash %q", key.PublicKeyHash)
+		}
+		return append([]byte{byte(tag)}, hash...), nil
+	}
+	return nil, fmt.Errorf("unsupported key algorithm %q", key.Algorithm)
+}
+
+// decodeSignature returns the 64 raw bytes of a base58check signature of any curve
+func decodeSignature(signature string) ([]byte, error) {
+	for _, prefix := range [][]byte{prefixEd25519Signature, prefixSecp256k1Signature, prefixP256Signature, prefixGenericSignature} {
+		if raw, err := base58CheckDecode(signature, prefix); err == nil && len(raw) == signatureSize {
+			return raw, nil
+		}
+	}
+	return nil, errors.New("invalid signature encoding")
+}
@@ -0,0 +1,44 @@
+package signing
+
+import (
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+var errInvalidSecp256k1Key = errors.New("invalid secp256k1 secret key")
+
+// parseSecp256k1Secret loads a 32-byte secret scalar, which must be in [1, n-1]
+func parseSecp256k1Secret(raw []byte) (*secp256k1.PrivateKey, error) {
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(raw); overflow || scalar.IsZero() {
+		return nil, errInvalidSecp256k1Key
+	}
+	return secp256k1.NewPrivateKey(&scalar), nil
+}
+
+// secp256k1Sign returns the r || s ECDSA signature of a 32-byte digest. The nonce is derived from the key
+// and the digest (RFC 6979) and s is low, as libsecp256k1 requires.
+func secp256k1Sign(key *secp256k1.PrivateKey, digest []byte) []byte {
+	signature := ecdsa.Sign(key, digest)
+	r, s := signature.R(), signature.S()
+
+	raw := make([]byte, 64)
+	r.PutBytesUnchecked(raw[:32])
+	s.PutBytesUnchecked(raw[32:])
+	return raw
+}
+
+// secp256k1Verify checks an r || s signature of digest against a compressed public key
+func secp256k1Verify(publicKey, digest, signature []byte) bool {
+	public, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return false
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(digest, public)
+}
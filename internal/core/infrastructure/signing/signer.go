@@ -0,0 +1,108 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	AlgorithmEd25519   = "ed25519"
+	AlgorithmSecp256k1 = "secp256k1"
+	AlgorithmP256      = "p256"
+)
+
+// Backend holds a signing key: a LocalSigner or a remote signer
+type Backend interface {
+	// PublicKey returns the base58check public key (edpk..., sppk... or p2pk...)
+	PublicKey(ctx context.Context) (string, error)
+	// Sign signs the Blake2b-256 digest of message, as CHECK_SIGNATURE verifies, and returns a base58check signature
+	Sign(ctx context.Context, message []byte) (string, error)
+}
+
+// Signer signs oracle payloads with the key of a backend
+type Signer struct {
+	backend Backend
+	key     oracle.Key
+}
+
+// NewSigner fetches the backend public key; when keyHash is set it must be the hash of that key
+func NewSigner(ctx context.Context, backend Backend, keyHash string) (*Signer, error) {
+	publicKey, err := backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer public key: %w", err)
+	}
+	key, err := keyFromPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if keyHash != "" && keyHash != key.PublicKeyHash {
+		return nil, fmt.Errorf("signer key %s does not match configured public key hash %s", key.PublicKeyHash, keyHash)
+	}
+
+	return &Signer{backend: backend, key: key}, nil
+}
+
+// Key returns the public key attestations are verifiable with
+func (s *Signer) Key() oracle.Key {
+	return s.key
+}
+
+// Attest packs the payload and has the backend sign it
+func (s *Signer) Attest(ctx context.Context, payload oracle.Payload) (oracle.Attestation, error) {
+	packed := Pack(payload)
+	signature, err := s.backend.Sign(ctx, packed)
+	if err != nil {
+		return oracle.Attestation{}, quotes.UpstreamUnavailable("signer", err)
+	}
+
+	return oracle.Attestation{
+		Payload:   payload,
+		Packed:    packed,
+		Signature: signature,
+		Key:       s.key,
+	}, nil
+}
+
+// SignCandle packs the candle and has the backend sign it
+func (s *Signer) SignCandle(ctx context.Context, candle oracle.Candle) (oracle.SignedCandle, error) {
+	packed := PackCandle(candle)
+	signature, err := s.backend.Sign(ctx, packed)
+	if err != nil {
+		return oracle.SignedCandle{}, quotes.UpstreamUnavailable("signer", err)
+	}
+
+	return oracle.SignedCandle{Candle: candle, Packed: packed, Signature: signature}, nil
+}
+
+// keyFromPublicKey derives the algorithm and key hash (Blake2b-160 of the public key) of a base58check public key
+func keyFromPublicKey(publicKey string) (oracle.Key, error) {
+	for _, format := range []struct {
+		algorithm string
+		prefix    []byte
+		size      int
+		hash      []byte
+	}{
+		{AlgorithmEd25519, prefixEd25519PublicKey, ed25519.PublicKeySize, prefixEd25519PublicKeyHash},
+		{AlgorithmSecp256k1, prefixSecp256k1PublicKey, 33, prefixSecp256k1KeyHash},
+		{AlgorithmP256, prefixP256PublicKey, 33, prefixP256PublicKeyHash},
+	} {
+		raw, err := base58CheckDecode(publicKey, format.prefix)
+		if err != nil || len(raw) != format.size {
+			continue
+		}
+
+		hash, _ := blake2b.New(20, nil)
+		hash.Write(raw)
+		return oracle.Key{
+			Algorithm:     format.algorithm,
+			PublicKey:     publicKey,
+			PublicKeyHash: base58CheckEncode(format.hash, hash.Sum(nil)),
+		}, nil
+	}
+	return oracle.Key{}, fmt.Errorf("unsupported signer public key %q", publicKey)
+}
@@ -0,0 +1,306 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestPack(t *testing.T) {
+	payload := oracle.Payload{
+		Timestamp: time.Unix(1735689600, 0).UTC(),
+		Token:     "mvrk",
+		Currency:  quotes.CurrencyUSD,
+		Price:     big.NewInt(12345678),
+		Decimals:  6,
+	}
+
+	// PACK (Pair 1735689600 (Pair "mvrk" (Pair "usd" 12345678)))
+	want := "050707008096a4f70c070701000000046d76726b07070100000003757364008e85e30b"
+	if got := hex.EncodeToString(Pack(payload)); got != want {
+		t.Errorf("Pack() = %s, want %s", got, want)
+	}
+}
+
+func TestAppendInt(t *testing.T) {
+	tests := []struct {
+		value int64
+		want  string
+	}{
+		{0, "0000"},
+		{63, "003f"},
+		{64, "008001"},
+		{300, "00ac04"},
+		{-1, "0041"},
+		{-64, "00c001"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(appendInt(nil, big.NewInt(tt.value))); got != tt.want {
+			t.Errorf("appendInt(%d) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBase58CheckPrefixes(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix []byte
+		size   int
+	}{
+		{"edpk", prefixEd25519PublicKey, 32},
+		{"sppk", prefixSecp256k1PublicKey, 33},
+		{"p2pk", prefixP256PublicKey, 33},
+		{"edsig", prefixEd25519Signature, 64},
+		{"spsig1", prefixSecp256k1Signature, 64},
+		{"p2sig", prefixP256Signature, 64},
+		{"mv1", prefixEd25519PublicKeyHash, 20},
+		{"mv2", prefixSecp256k1KeyHash, 20},
+		{"mv3", prefixP256PublicKeyHash, 20},
+	}
+	for _, tt := range tests {
+		// The smallest and largest payloads bound every encoding of that size
+		for _, fill := range []byte{0x00, 0xff} {
+			payload := bytes.Repeat([]byte{fill}, tt.size)
+			encoded := base58CheckEncode(tt.prefix, payload)
+			if !strings.HasPrefix(encoded, tt.name) {
+				t.Errorf("%s: encoding of %x starts with %q", tt.name, fill, encoded[:len(tt.name)])
+			}
+			decoded, err := base58CheckDecode(encoded, tt.prefix)
+			if err != nil || !bytes.Equal(decoded, payload) {
+				t.Errorf("%s: decode(%s) = %x, %v", tt.name, encoded, decoded, err)
+			}
+		}
+	}
+}
+
+func TestKeyFromPublicKey(t *testing.T) {
+	// Tezos sandbox bootstrap1 key: its tz1 hash is the same Blake2b-160 digest with another prefix
+	signer, err := NewLocalSigner("edsk3gUfUPyBSfrS9CCgmCiQsTCHGkviBDusMxDJstFtojtc1zcpsh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, _ := signer.PublicKey(context.Background())
+	if publicKey != "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav" {
+		t.Fatalf("public key = %s", publicKey)
+	}
+
+	key, err := keyFromPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := base58CheckDecode(key.PublicKeyHash, prefixEd25519PublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tz1 := base58CheckEncode([]byte{6, 161, 159}, hash); tz1 != "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx" {
+		t.Errorf("key hash %s is %s on Tezos", key.PublicKeyHash, tz1)
+	}
+	if key.Algorithm != AlgorithmEd25519 {
+		t.Errorf("algorithm = %s", key.Algorithm)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x2a}, 32)
+	message := Pack(oracle.Payload{Timestamp: time.Unix(1735689600, 0), Token: "mvrk", Currency: quotes.CurrencyUSD, Price: big.NewInt(1)})
+	digest := blake2b.Sum256(message)
+
+	tests := []struct {
+		name            string
+		secretKey       string
+		signaturePrefix []byte
+		verify          func(publicKey, signature []byte) bool
+	}{
+		{
+			name:            "ed25519",
+			secretKey:       base58CheckEncode(prefixEd25519Seed, secret),
+			signaturePrefix: prefixEd25519Signature,
+			verify: func(publicKey, signature []byte) bool {
+				return ed25519.Verify(publicKey, digest[:], signature)
+			},
+		},
+		{
+			name:            "secp256k1",
+			secretKey:       base58CheckEncode(prefixSecp256k1SecretKey, secret),
+			signaturePrefix: prefixSecp256k1Signature,
+			verify: func(publicKey, signature []byte) bool {
+				return referenceSecp256k1PublicKey(secret) == hex.EncodeToString(publicKey) &&
+					referenceSecp256k1Verify(publicKey, digest[:], signature)
+			},
+		},
+		{
+			name:            "p256",
+			secretKey:       base58CheckEncode(prefixP256SecretKey, secret),
+			signaturePrefix: prefixP256Signature,
+			verify: func(publicKey, signature []byte) bool {
+				x, y := elliptic.UnmarshalCompressed(elliptic.P256(), publicKey)
+				if x == nil {
+					return false
+				}
+				r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+				return ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest[:], r, s)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewLocalSigner(tt.secretKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			publicKey, _ := signer.PublicKey(context.Background())
+			key, err := keyFromPublicKey(publicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key.Algorithm != tt.name {
+				t.Errorf("algorithm = %s", key.Algorithm)
+			}
+
+			signature, err := signer.Sign(context.Background(), message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rawSignature, err := base58CheckDecode(signature, tt.signaturePrefix)
+			if err != nil || len(rawSignature) != 64 {
+				t.Fatalf("signature %s: %v", signature, err)
+			}
+			rawPublicKey, _ := base58CheckDecode(publicKey, map[string][]byte{
+				AlgorithmEd25519:   prefixEd25519PublicKey,
+				AlgorithmSecp256k1: prefixSecp256k1PublicKey,
+				AlgorithmP256:      prefixP256PublicKey,
+			}[tt.name])
+			if !tt.verify(rawPublicKey, rawSignature) {
+				t.Errorf("signature %s does not verify with %s", signature, publicKey)
+			}
+			if err := Verify(publicKey, message, signature); err != nil {
+				t.Errorf("Verify() = %v", err)
+			}
+			if err := Verify(publicKey, append([]byte{0x05}, message...), signature); err == nil {
+				t.Error("Verify() accepted another message")
+			}
+		})
+	}
+}
+
+func TestSecp256k1SignIsDeterministicWithLowS(t *testing.T) {
+	signer, err := NewLocalSigner(base58CheckEncode(prefixSecp256k1SecretKey, bytes.Repeat([]byte{0x07}, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := signer.Sign(context.Background(), []byte("message"))
+	second, _ := signer.Sign(context.Background(), []byte("message"))
+	if first != second {
+		t.Errorf("RFC 6979 signatures differ: %s and %s", first, second)
+	}
+
+	raw, _ := base58CheckDecode(first, prefixSecp256k1Signature)
+	s := new(big.Int).SetBytes(raw[32:])
+	if s.Cmp(new(big.Int).Rsh(secp256k1Order, 1)) > 0 {
+		t.Errorf("s is not low: %x", s)
+	}
+}
+
+func TestSecp256k1RejectsOutOfRangeSecrets(t *testing.T) {
+	for _, secret := range [][]byte{make([]byte, 32), secp256k1Order.FillBytes(make([]byte, 32))} {
+		if _, err := NewLocalSigner(base58CheckEncode(prefixSecp256k1SecretKey, secret)); err == nil {
+			t.Errorf("secret %x accepted", secret)
+		}
+	}
+}
+
+// Reference secp256k1 arithmetic (SEC 2, affine coordinates), independent of the signing library
+
+var (
+	secp256k1Field, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	secp256k1Order, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	secp256k1Gx, _    = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	secp256k1Gy, _    = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+)
+
+type referencePoint struct{ x, y *big.Int } // nil x is the point at infinity
+
+func referenceAdd(a, b referencePoint) referencePoint {
+	p := secp256k1Field
+	if a.x == nil {
+		return b
+	}
+	if b.x == nil {
+		return a
+	}
+	var slope *big.Int
+	if a.x.Cmp(b.x) == 0 {
+		if new(big.Int).Add(a.y, b.y).Mod(new(big.Int).Add(a.y, b.y), p).Sign() == 0 {
+			return referencePoint{}
+		}
+		num := new(big.Int).Mul(big.NewInt(3), new(big.Int).Mul(a.x, a.x))
+		slope = num.Mul(num, new(big.Int).ModInverse(new(big.Int).Lsh(a.y, 1), p))
+	} else {
+		num := new(big.Int).Sub(b.y, a.y)
+		den := new(big.Int).Mod(new(big.Int).Sub(b.x, a.x), p)
+		slope = num.Mul(num, den.ModInverse(den, p))
+	}
+	slope.Mod(slope, p)
+	x := new(big.Int).Mul(slope, slope)
+	x.Sub(x, a.x).Sub(x, b.x).Mod(x, p)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, slope).Sub(y, a.y).Mod(y, p)
+	return referencePoint{x, y}
+}
+
+func referenceMul(k *big.Int, point referencePoint) referencePoint {
+	result := referencePoint{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = referenceAdd(result, result)
+		if k.Bit(i) == 1 {
+			result = referenceAdd(result, point)
+		}
+	}
+	return result
+}
+
+func referenceSecp256k1PublicKey(secret []byte) string {
+	public := referenceMul(new(big.Int).SetBytes(secret), referencePoint{secp256k1Gx, secp256k1Gy})
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 | byte(public.y.Bit(0))
+	public.x.FillBytes(compressed[1:])
+	return hex.EncodeToString(compressed)
+}
+
+func referenceSecp256k1Verify(publicKey, digest, signature []byte) bool {
+	p, n := secp256k1Field, secp256k1Order
+
+	// Decompress: y² = x³ + 7, p = 3 mod 4 so y = (y²)^((p+1)/4)
+	x := new(big.Int).SetBytes(publicKey[1:])
+	ySquared := new(big.Int).Exp(x, big.NewInt(3), p)
+	ySquared.Add(ySquared, big.NewInt(7)).Mod(ySquared, p)
+	y := new(big.Int).Exp(ySquared, new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2), p)
+	if y.Bit(0) != uint(publicKey[0]&1) {
+		y.Sub(p, y)
+	}
+
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if r.Sign() == 0 || r.Cmp(n) >= 0 || s.Sign() == 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	w := new(big.Int).ModInverse(s, n)
+	u1 := new(big.Int).Mul(new(big.Int).SetBytes(digest), w)
+	u2 := new(big.Int).Mul(r, w)
+	point := referenceAdd(
+		referenceMul(u1.Mod(u1, n), referencePoint{secp256k1Gx, secp256k1Gy}),
+		referenceMul(u2.Mod(u2, n), referencePoint{x, y}),
+	)
+	return point.x != nil && new(big.Int).Mod(point.x, n).Cmp(r) == 0
+}
@@ -0,0 +1,49 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+var errSignatureMismatch = errors.New("signature does not match the public key and message")
+
+// Verify checks that signature (edsig..., spsig1..., p2sig... or sig...) signs the Blake2b-256 digest
+// of message with publicKey, as CHECK_SIGNATURE does
+func Verify(publicKey string, message []byte, signature string) error {
+	key, err := keyFromPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	rawSignature, err := decodeSignature(signature)
+	if err != nil {
+		return err
+	}
+	digest := blake2b.Sum256(message)
+
+	var valid bool
+	switch key.Algorithm {
+	case AlgorithmEd25519:
+		raw, _ := base58CheckDecode(publicKey, prefixEd25519PublicKey)
+		valid = ed25519.Verify(raw, digest[:], rawSignature)
+	case AlgorithmSecp256k1:
+		raw, _ := base58CheckDecode(publicKey, prefixSecp256k1PublicKey)
+		valid = secp256k1Verify(raw, digest[:], rawSignature)
+	case AlgorithmP256:
+		raw, _ := base58CheckDecode(publicKey, prefixP256PublicKey)
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), raw)
+		if x == nil {
+			return errors.New("invalid p256 public key")
+		}
+		r, s := new(big.Int).SetBytes(rawSignature[:32]), new(big.Int).SetBytes(rawSignature[32:])
+		valid = ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest[:], r, s)
+	}
+	if !valid {
+		return errSignatureMismatch
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// APIKeyEntity is a client API key stored by hash
+type APIKeyEntity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	KeyHash   string    `gorm:"not null;uniqueIndex" json:"-"`
+	Tier      string    `gorm:"not null" json:"tier"`
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (APIKeyEntity) TableName() string {
+	return "mev.api_keys"
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// BasketSnapshotEntity records the constituents a basket point was computed with
+type BasketSnapshotEntity struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Basket       string    `gorm:"not null" json:"basket"`
+	Timestamp    time.Time `gorm:"not null" json:"timestamp"`
+	RebalancedAt time.Time `gorm:"not null" json:"rebalanced_at"`
+	Constituents string    `gorm:"type:jsonb;not null" json:"constituents"` // JSON array of quotes.BasketHolding
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (BasketSnapshotEntity) TableName() string {
+	return "mev.basket_snapshots"
+}
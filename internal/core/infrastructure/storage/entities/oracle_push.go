@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// OraclePushEntity is a price update submitted to an oracle contract
+type OraclePushEntity struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	Contract       string    `gorm:"not null" json:"contract"`
+	Token          string    `gorm:"not null" json:"token"`
+	Currency       string    `gorm:"not null" json:"currency"`
+	QuoteTimestamp time.Time `gorm:"not null" json:"quote_timestamp"`
+	PriceNat       string    `gorm:"type:numeric(78,0);not null" json:"price_nat"`
+	Decimals       int16     `gorm:"not null" json:"decimals"`
+	Reason         string    `gorm:"not null" json:"reason"`
+	OperationHash  string    `gorm:"not null" json:"operation_hash"`
+	PushedAt       time.Time `gorm:"not null" json:"pushed_at"`
+	Status         string    `gorm:"not null" json:"status"`
+	InjectedLevel  int64     `gorm:"not null" json:"injected_level"`
+	IncludedLevel  *int64    `json:"included_level"`
+}
+
+func (OraclePushEntity) TableName() string {
+	return "mev.oracle_pushes"
+}
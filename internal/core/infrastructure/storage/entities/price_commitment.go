@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// PriceCommitmentEntity is the Merkle root of the quotes a token stored during a UTC day
+type PriceCommitmentEntity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Token     string    `gorm:"not null" json:"token"`
+	Day       time.Time `gorm:"type:date;not null" json:"day"`
+	Root      string    `gorm:"not null" json:"root"` // hex SHA-256
+	LeafCount int       `gorm:"not null" json:"leaf_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (PriceCommitmentEntity) TableName() string {
+	return "mev.price_commitments"
+}
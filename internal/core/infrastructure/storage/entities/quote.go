@@ -8,19 +8,21 @@ import (
 // QuoteEntity is a universal entity for all token tables
 // Table name is set dynamically
 type QuoteEntity struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Timestamp time.Time      `gorm:"not null;index" json:"timestamp"`
-	BTC       float64        `gorm:"type:decimal(20,8)" json:"btc"`
-	USD       float64        `gorm:"type:decimal(20,8)" json:"usd"`
-	EUR       float64        `gorm:"type:decimal(20,8)" json:"eur"`
-	CNY       float64        `gorm:"type:decimal(20,8)" json:"cny"`
-	JPY       float64        `gorm:"type:decimal(20,8)" json:"jpy"`
-	KRW       float64        `gorm:"type:decimal(20,8)" json:"krw"`
-	ETH       float64        `gorm:"type:decimal(20,8)" json:"eth"`
-	GBP       float64        `gorm:"type:decimal(20,8)" json:"gbp"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	Timestamp    time.Time      `gorm:"not null;index" json:"timestamp"`
+	BTC          float64        `gorm:"type:decimal(20,8)" json:"btc"`
+	USD          float64        `gorm:"type:decimal(20,8)" json:"usd"`
+	EUR          float64        `gorm:"type:decimal(20,8)" json:"eur"`
+	CNY          float64        `gorm:"type:decimal(20,8)" json:"cny"`
+	JPY          float64        `gorm:"type:decimal(20,8)" json:"jpy"`
+	KRW          float64        `gorm:"type:decimal(20,8)" json:"krw"`
+	ETH          float64        `gorm:"type:decimal(20,8)" json:"eth"`
+	GBP          float64        `gorm:"type:decimal(20,8)" json:"gbp"`
+	Volume24hUSD float64        `gorm:"column:volume_24h_usd;type:decimal(30,8)" json:"volume_24h_usd"`
+	Filled       int16          `gorm:"not null;default:0" json:"filled"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 func (QuoteEntity) TableName() string {
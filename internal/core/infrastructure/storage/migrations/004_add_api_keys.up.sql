@@ -0,0 +1,15 @@
+-- Create api_keys table in mev schema
+-- Keys are stored as hex-encoded SHA-256 hashes, never in plain text
+
+CREATE TABLE IF NOT EXISTS mev.api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    tier VARCHAR(50) NOT NULL DEFAULT 'partner',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mev_api_keys_key_hash
+    ON mev.api_keys (key_hash);
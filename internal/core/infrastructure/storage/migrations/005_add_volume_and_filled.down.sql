@@ -0,0 +1,19 @@
+-- Drop volume and forward-fill markers from every token table
+
+DO $$
+DECLARE
+    token_table TEXT;
+BEGIN
+    FOR token_table IN
+        SELECT table_name
+        FROM information_schema.columns
+        WHERE table_schema = 'mev'
+        AND column_name = 'gbp'
+    LOOP
+        EXECUTE format('
+            ALTER TABLE mev.%I
+                DROP COLUMN IF EXISTS volume_24h_usd,
+                DROP COLUMN IF EXISTS filled', token_table);
+    END LOOP;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,21 @@
+-- Add the rolling 24h trading volume (USD) reported with each quote and forward-fill markers to every token table
+-- filled has one bit per currency (btc, usd, eur, cny, jpy, krw, eth, gbp) set when the price was forward-filled
+-- Token tables are the tables of the mev schema with the price columns, so tokens added to config.yaml are covered too
+
+DO $$
+DECLARE
+    token_table TEXT;
+BEGIN
+    FOR token_table IN
+        SELECT table_name
+        FROM information_schema.columns
+        WHERE table_schema = 'mev'
+        AND column_name = 'gbp'
+    LOOP
+        EXECUTE format('
+            ALTER TABLE mev.%I
+                ADD COLUMN IF NOT EXISTS volume_24h_usd DECIMAL(30,8) DEFAULT 0,
+                ADD COLUMN IF NOT EXISTS filled SMALLINT NOT NULL DEFAULT 0', token_table);
+    END LOOP;
+END
+$$ LANGUAGE plpgsql;
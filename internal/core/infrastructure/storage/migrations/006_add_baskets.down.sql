@@ -0,0 +1,5 @@
+-- Basket token tables created by mev.create_token_table are kept; drop them manually if needed
+
+DROP TABLE IF EXISTS mev.basket_snapshots;
+
+DROP FUNCTION IF EXISTS mev.create_token_table(TEXT);
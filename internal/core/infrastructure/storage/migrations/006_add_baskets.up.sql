@@ -0,0 +1,62 @@
+-- Composite (basket) tokens: a function creating token tables on demand, and the constituents
+-- snapshot recorded with every computed basket point
+
+-- Creates a token table with the layout of mev.mvrk / mev.usdt (including migration 005).
+-- The service calls it at startup for every basket in config.yaml.
+CREATE OR REPLACE FUNCTION mev.create_token_table(token_name TEXT)
+RETURNS VOID AS $$
+BEGIN
+    IF token_name !~ '^[a-z][a-z0-9_]*$' THEN
+        RAISE EXCEPTION 'invalid token table name: %', token_name;
+    END IF;
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS mev.%I (
+            id SERIAL PRIMARY KEY,
+            timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+            btc DECIMAL(20,8) DEFAULT 0,
+            usd DECIMAL(20,8) DEFAULT 0,
+            eur DECIMAL(20,8) DEFAULT 0,
+            cny DECIMAL(20,8) DEFAULT 0,
+            jpy DECIMAL(20,8) DEFAULT 0,
+            krw DECIMAL(20,8) DEFAULT 0,
+            eth DECIMAL(20,8) DEFAULT 0,
+            gbp DECIMAL(20,8) DEFAULT 0,
+            volume_24h_usd DECIMAL(30,8) DEFAULT 0,
+            filled SMALLINT NOT NULL DEFAULT 0,
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+            updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+            deleted_at TIMESTAMP WITH TIME ZONE
+        )', token_name);
+
+    IF EXISTS (
+        SELECT 1
+        FROM pg_extension
+        WHERE extname = 'timescaledb'
+    ) THEN
+        PERFORM create_hypertable(
+            format('mev.%I', token_name)::regclass,
+            'timestamp',
+            if_not_exists => TRUE
+        );
+    END IF;
+
+    EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON mev.%I (timestamp)', 'idx_mev_' || token_name || '_timestamp', token_name);
+    EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON mev.%I (timestamp DESC)', 'idx_mev_' || token_name || '_timestamp_desc', token_name);
+    EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON mev.%I (deleted_at)', 'idx_mev_' || token_name || '_deleted_at', token_name);
+END
+$$ LANGUAGE plpgsql;
+
+-- One row per computed basket point: the holdings (weight, units, USD price and quote
+-- timestamp of every constituent) the point was computed with
+CREATE TABLE IF NOT EXISTS mev.basket_snapshots (
+    id SERIAL PRIMARY KEY,
+    basket VARCHAR(50) NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    rebalanced_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    constituents JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mev_basket_snapshots_basket_timestamp
+    ON mev.basket_snapshots (basket, timestamp DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mev.oracle_pushes;
@@ -0,0 +1,24 @@
+-- Price updates submitted to oracle contracts, used to apply the deviation and heartbeat policy.
+-- An update only counts once its operation is found in a block (status 'included').
+
+CREATE TABLE IF NOT EXISTS mev.oracle_pushes (
+    id SERIAL PRIMARY KEY,
+    contract VARCHAR(36) NOT NULL,
+    token VARCHAR(50) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    quote_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    price_nat NUMERIC(78,0) NOT NULL,
+    decimals SMALLINT NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    operation_hash VARCHAR(51) NOT NULL,
+    pushed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    injected_level BIGINT NOT NULL,
+    included_level BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_mev_oracle_pushes_feed
+    ON mev.oracle_pushes (contract, token, currency, pushed_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_mev_oracle_pushes_pending
+    ON mev.oracle_pushes (contract, operation_hash) WHERE status = 'pending';
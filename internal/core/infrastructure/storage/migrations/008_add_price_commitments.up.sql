@@ -0,0 +1,13 @@
+-- Daily Merkle roots of the stored quotes of every token, served with inclusion proofs by /v1/proofs
+
+CREATE TABLE IF NOT EXISTS mev.price_commitments (
+    id SERIAL PRIMARY KEY,
+    token VARCHAR(50) NOT NULL,
+    day DATE NOT NULL,
+    root CHAR(64) NOT NULL,
+    leaf_count INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mev_price_commitments_token_day
+    ON mev.price_commitments (token, day DESC);
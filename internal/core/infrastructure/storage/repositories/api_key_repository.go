@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"quotes/internal/core/domain/apikeys"
+	"quotes/internal/core/infrastructure/storage/entities"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// FindByKey looks up an enabled API key by its raw value
+func (r *APIKeyRepository) FindByKey(ctx context.Context, key string) (apikeys.APIKey, error) {
+	var entity entities.APIKeyEntity
+
+	result := r.db.WithContext(ctx).
+		Where("key_hash = ? AND enabled = ?", apikeys.HashKey(key), true).
+		First(&entity)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return apikeys.APIKey{}, apikeys.ErrKeyNotFound
+		}
+		return apikeys.APIKey{}, fmt.Errorf("failed to find api key: %w", result.Error)
+	}
+
+	return apikeys.APIKey{
+		Name: entity.Name,
+		Tier: apikeys.Tier(entity.Tier),
+	}, nil
+}
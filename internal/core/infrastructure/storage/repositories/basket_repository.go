@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/storage/entities"
+
+	"gorm.io/gorm"
+)
+
+type BasketRepository struct {
+	db *gorm.DB
+}
+
+func NewBasketRepository(db *gorm.DB) *BasketRepository {
+	return &BasketRepository{db: db}
+}
+
+// EnsureTable creates the quotes table of a basket if needed (mev.create_token_table, migration 006)
+func (r *BasketRepository) EnsureTable(ctx context.Context, basketName string) error {
+	result := r.db.WithContext(ctx).Exec("SELECT mev.create_token_table(?)", tokenNameToTableName(basketName))
+	if result.Error != nil {
+		return fmt.Errorf("failed to create table for basket %s: %w", basketName, result.Error)
+	}
+	return nil
+}
+
+// SavePoint saves a basket quote together with the snapshot it was computed from
+func (r *BasketRepository) SavePoint(ctx context.Context, quote quotes.Quote, snapshot quotes.BasketSnapshot) error {
+	constituents, err := json.Marshal(snapshot.Holdings)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of basket %s: %w", snapshot.Basket, err)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := NewQuoteRepository(tx).Save(ctx, quote, snapshot.Basket); err != nil {
+			return err
+		}
+
+		entity := &entities.BasketSnapshotEntity{
+			Basket:       snapshot.Basket,
+			Timestamp:    snapshot.Timestamp,
+			RebalancedAt: snapshot.RebalancedAt,
+			Constituents: string(constituents),
+		}
+		if result := tx.Create(entity); result.Error != nil {
+			return quotes.UpstreamUnavailable("database", fmt.Errorf("failed to save snapshot of basket %s: %w", snapshot.Basket, result.Error))
+		}
+		return nil
+	})
+}
+
+// GetLastSnapshot retrieves the snapshot of the latest point of a basket
+func (r *BasketRepository) GetLastSnapshot(ctx context.Context, basketName string) (quotes.BasketSnapshot, error) {
+	var entity entities.BasketSnapshotEntity
+
+	result := r.db.WithContext(ctx).
+		Where("basket = ?", basketName).
+		Order("timestamp DESC").
+		First(&entity)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return quotes.BasketSnapshot{}, quotes.NoData(basketName)
+		}
+		return quotes.BasketSnapshot{}, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get snapshot of basket %s: %w", basketName, result.Error))
+	}
+
+	snapshot := quotes.BasketSnapshot{
+		Basket:       entity.Basket,
+		Timestamp:    entity.Timestamp,
+		RebalancedAt: entity.RebalancedAt,
+	}
+	if err := json.Unmarshal([]byte(entity.Constituents), &snapshot.Holdings); err != nil {
+		return quotes.BasketSnapshot{}, fmt.Errorf("failed to decode snapshot of basket %s: %w", basketName, err)
+	}
+	return snapshot, nil
+}
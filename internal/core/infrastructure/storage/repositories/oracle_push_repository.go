@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"quotes/internal/core/domain/oracle"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/storage/entities"
+
+	"gorm.io/gorm"
+)
+
+type OraclePushRepository struct {
+	db *gorm.DB
+}
+
+func NewOraclePushRepository(db *gorm.DB) *OraclePushRepository {
+	return &OraclePushRepository{db: db}
+}
+
+// Save records an update submitted to an oracle contract, normally as pending
+func (r *OraclePushRepository) Save(ctx context.Context, push oracle.Push) error {
+	entity := &entities.OraclePushEntity{
+		Contract:       push.Contract,
+		Token:          push.Payload.Token,
+		Currency:       string(push.Payload.Currency),
+		QuoteTimestamp: push.Payload.Timestamp,
+		PriceNat:       push.Payload.Price.String(),
+		Decimals:       int16(push.Payload.Decimals),
+		Reason:         string(push.Reason),
+		OperationHash:  push.OperationHash,
+		PushedAt:       push.PushedAt,
+		Status:         string(push.Status),
+		InjectedLevel:  push.InjectedLevel,
+	}
+	if push.IncludedLevel > 0 {
+		entity.IncludedLevel = &push.IncludedLevel
+	}
+
+	if result := r.db.WithContext(ctx).Create(entity); result.Error != nil {
+		return quotes.UpstreamUnavailable("database", fmt.Errorf("failed to save oracle push %s: %w", push.OperationHash, result.Error))
+	}
+	return nil
+}
+
+// GetLast returns the last update of a feed submitted to a contract that did not fail, pending or included,
+// or nil when there is none
+func (r *OraclePushRepository) GetLast(ctx context.Context, contract, tokenName string, currency quotes.Currency) (*oracle.Push, error) {
+	var entity entities.OraclePushEntity
+
+	result := r.db.WithContext(ctx).
+		Where("contract = ? AND token = ? AND currency = ? AND status <> ?", contract, tokenName, string(currency), string(oracle.PushFailed)).
+		Order("pushed_at DESC").
+		First(&entity)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get last oracle push: %w", result.Error))
+	}
+
+	push, err := toPush(entity)
+	if err != nil {
+		return nil, err
+	}
+	return &push, nil
+}
+
+// GetPending returns the updates submitted to a contract whose operation was not found in a block yet
+func (r *OraclePushRepository) GetPending(ctx context.Context, contract string) ([]oracle.Push, error) {
+	var entityList []entities.OraclePushEntity
+
+	result := r.db.WithContext(ctx).
+		Where("contract = ? AND status = ?", contract, string(oracle.PushPending)).
+		Order("pushed_at").
+		Find(&entityList)
+	if result.Error != nil {
+		return nil, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get pending oracle pushes: %w", result.Error))
+	}
+
+	pushes := make([]oracle.Push, 0, len(entityList))
+	for _, entity := range entityList {
+		push, err := toPush(entity)
+		if err != nil {
+			return nil, err
+		}
+		pushes = append(pushes, push)
+	}
+	return pushes, nil
+}
+
+// SetStatus resolves the pending updates carried by an operation; level is the including block (0 if failed)
+func (r *OraclePushRepository) SetStatus(ctx context.Context, operationHash string, status oracle.PushStatus, level int64) error {
+	updates := map[string]any{"status": string(status)}
+	if level > 0 {
+		updates["included_level"] = level
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&entities.OraclePushEntity{}).
+		Where("operation_hash = ? AND status = ?", operationHash, string(oracle.PushPending)).
+		Updates(updates)
+	if result.Error != nil {
+		return quotes.UpstreamUnavailable("database", fmt.Errorf("failed to update oracle push %s: %w", operationHash, result.Error))
+	}
+	return nil
+}
+
+func toPush(entity entities.OraclePushEntity) (oracle.Push, error) {
+	price, ok := new(big.Int).SetString(entity.PriceNat, 10)
+	if !ok {
+		return oracle.Push{}, fmt.Errorf("invalid stored oracle price %q", entity.PriceNat)
+	}
+
+	push := oracle.Push{
+		Contract: entity.Contract,
+		Payload: oracle.Payload{
+			Timestamp: entity.QuoteTimestamp,
+			Token:     entity.Token,
+			Currency:  quotes.Currency(entity.Currency),
+			Price:     price,
+			Decimals:  int(entity.Decimals),
+		},
+		Reason:        oracle.PushReason(entity.Reason),
+		OperationHash: entity.OperationHash,
+		PushedAt:      entity.PushedAt,
+		Status:        oracle.PushStatus(entity.Status),
+		InjectedLevel: entity.InjectedLevel,
+	}
+	if entity.IncludedLevel != nil {
+		push.IncludedLevel = *entity.IncludedLevel
+	}
+	return push, nil
+}
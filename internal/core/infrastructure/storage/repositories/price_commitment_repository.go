@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"quotes/internal/core/domain/proofs"
+	"quotes/internal/core/domain/quotes"
+	"quotes/internal/core/infrastructure/storage/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PriceCommitmentRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceCommitmentRepository(db *gorm.DB) *PriceCommitmentRepository {
+	return &PriceCommitmentRepository{db: db}
+}
+
+// Save stores the root of a day; a day already committed keeps its first root
+func (r *PriceCommitmentRepository) Save(ctx context.Context, commitment proofs.Commitment) error {
+	entity := &entities.PriceCommitmentEntity{
+		Token:     commitment.Token,
+		Day:       commitment.Day,
+		Root:      commitment.Root.String(),
+		LeafCount: commitment.LeafCount,
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "token"}, {Name: "day"}}, DoNothing: true}).
+		Create(entity)
+	if result.Error != nil {
+		return quotes.UpstreamUnavailable("database", fmt.Errorf("failed to save commitment of %s for %s: %w", commitment.Token, commitment.Day.Format(time.DateOnly), result.Error))
+	}
+	return nil
+}
+
+// Get returns the commitment of a token for a day, or a NoData error when the day is not committed
+func (r *PriceCommitmentRepository) Get(ctx context.Context, tokenName string, day time.Time) (proofs.Commitment, error) {
+	var entity entities.PriceCommitmentEntity
+
+	result := r.db.WithContext(ctx).
+		Where("token = ? AND day = ?", tokenName, day.Format(time.DateOnly)).
+		First(&entity)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return proofs.Commitment{}, &quotes.Error{
+				Kind:    quotes.ErrNoData,
+				Message: fmt.Sprintf("quotes of token '%s' for %s are not committed", tokenName, day.Format(time.DateOnly)),
+			}
+		}
+		return proofs.Commitment{}, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get commitment of %s: %w", tokenName, result.Error))
+	}
+	return toCommitment(entity)
+}
+
+// GetLastDay returns the last committed day of a token; ok is false when none is
+func (r *PriceCommitmentRepository) GetLastDay(ctx context.Context, tokenName string) (day time.Time, ok bool, err error) {
+	var entity entities.PriceCommitmentEntity
+
+	result := r.db.WithContext(ctx).
+		Where("token = ?", tokenName).
+		Order("day DESC").
+		First(&entity)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get last commitment of %s: %w", tokenName, result.Error))
+	}
+	return proofs.DayStart(entity.Day), true, nil
+}
+
+func toCommitment(entity entities.PriceCommitmentEntity) (proofs.Commitment, error) {
+	root, err := proofs.ParseHash(entity.Root)
+	if err != nil {
+		return proofs.Commitment{}, fmt.Errorf("invalid stored commitment of %s: %w", entity.Token, err)
+	}
+	return proofs.Commitment{
+		Token:       entity.Token,
+		Day:         proofs.DayStart(entity.Day),
+		Root:        root,
+		LeafCount:   entity.LeafCount,
+		CommittedAt: entity.CreatedAt,
+	}, nil
+}
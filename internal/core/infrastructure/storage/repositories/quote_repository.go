@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"quotes/internal/core/domain/quotes"
 	"quotes/internal/core/infrastructure/storage/entities"
@@ -30,25 +32,27 @@ func NewQuoteRepository(db *gorm.DB) *QuoteRepository {
 // Save saves a quote for a specific token
 func (r *QuoteRepository) Save(ctx context.Context, quote quotes.Quote, tokenName string) error {
 	if !quotes.IsTokenSupported(tokenName) {
-		return fmt.Errorf("token '%s' is not supported", tokenName)
+		return quotes.TokenNotFound(tokenName)
 	}
 
 	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
 	entity := &entities.QuoteEntity{
-		Timestamp: quote.Timestamp,
-		BTC:       quote.BTC,
-		USD:       quote.USD,
-		EUR:       quote.EUR,
-		CNY:       quote.CNY,
-		JPY:       quote.JPY,
-		KRW:       quote.KRW,
-		ETH:       quote.ETH,
-		GBP:       quote.GBP,
+		Timestamp:    quote.Timestamp,
+		BTC:          quote.BTC,
+		USD:          quote.USD,
+		EUR:          quote.EUR,
+		CNY:          quote.CNY,
+		JPY:          quote.JPY,
+		KRW:          quote.KRW,
+		ETH:          quote.ETH,
+		GBP:          quote.GBP,
+		Volume24hUSD: quote.Volume24h,
+		Filled:       int16(quote.Filled),
 	}
 
 	result := r.db.WithContext(ctx).Table(tableName).Create(entity)
 	if result.Error != nil {
-		return fmt.Errorf("failed to save quote for token %s: %w", tokenName, result.Error)
+		return quotes.UpstreamUnavailable("database", fmt.Errorf("failed to save quote for token %s: %w", tokenName, result.Error))
 	}
 
 	return nil
@@ -61,28 +65,30 @@ func (r *QuoteRepository) SaveBatch(ctx context.Context, quotesList []quotes.Quo
 	}
 
 	if !quotes.IsTokenSupported(tokenName) {
-		return fmt.Errorf("token '%s' is not supported", tokenName)
+		return quotes.TokenNotFound(tokenName)
 	}
 
 	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
 	quoteEntities := make([]entities.QuoteEntity, len(quotesList))
 	for i, quote := range quotesList {
 		quoteEntities[i] = entities.QuoteEntity{
-			Timestamp: quote.Timestamp,
-			BTC:       quote.BTC,
-			USD:       quote.USD,
-			EUR:       quote.EUR,
-			CNY:       quote.CNY,
-			JPY:       quote.JPY,
-			KRW:       quote.KRW,
-			ETH:       quote.ETH,
-			GBP:       quote.GBP,
+			Timestamp:    quote.Timestamp,
+			BTC:          quote.BTC,
+			USD:          quote.USD,
+			EUR:          quote.EUR,
+			CNY:          quote.CNY,
+			JPY:          quote.JPY,
+			KRW:          quote.KRW,
+			ETH:          quote.ETH,
+			GBP:          quote.GBP,
+			Volume24hUSD: quote.Volume24h,
+			Filled:       int16(quote.Filled),
 		}
 	}
 
 	result := r.db.WithContext(ctx).Table(tableName).CreateInBatches(quoteEntities, 100)
 	if result.Error != nil {
-		return fmt.Errorf("failed to save quotes batch for token %s: %w", tokenName, result.Error)
+		return quotes.UpstreamUnavailable("database", fmt.Errorf("failed to save quotes batch for token %s: %w", tokenName, result.Error))
 	}
 
 	return nil
@@ -91,7 +97,7 @@ func (r *QuoteRepository) SaveBatch(ctx context.Context, quotesList []quotes.Quo
 // GetLastQuote retrieves the last quote for a specific token
 func (r *QuoteRepository) GetLastQuote(ctx context.Context, tokenName string) (quotes.Quote, error) {
 	if !quotes.IsTokenSupported(tokenName) {
-		return quotes.Quote{}, fmt.Errorf("token '%s' is not supported", tokenName)
+		return quotes.Quote{}, quotes.TokenNotFound(tokenName)
 	}
 
 	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
@@ -103,10 +109,10 @@ func (r *QuoteRepository) GetLastQuote(ctx context.Context, tokenName string) (q
 		First(&entity)
 
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return quotes.Quote{}, fmt.Errorf("no quotes found for token '%s'", tokenName)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return quotes.Quote{}, quotes.NoData(tokenName)
 		}
-		return quotes.Quote{}, fmt.Errorf("failed to get last quote for token %s: %w", tokenName, result.Error)
+		return quotes.Quote{}, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get last quote for token %s: %w", tokenName, result.Error))
 	}
 
 	return r.entityToDomain(entity), nil
@@ -117,7 +123,11 @@ func (r *QuoteRepository) GetLastQuote(ctx context.Context, tokenName string) (q
 // Otherwise, returns quotes within the time range
 func (r *QuoteRepository) GetQuotes(ctx context.Context, from, to time.Time, limit int, tokenName string) ([]quotes.Quote, error) {
 	if !quotes.IsTokenSupported(tokenName) {
-		return nil, fmt.Errorf("token '%s' is not supported", tokenName)
+		return nil, quotes.TokenNotFound(tokenName)
+	}
+
+	if from.After(to) {
+		return nil, quotes.InvalidRange("invalid time range: 'from' must be before 'to'")
 	}
 
 	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
@@ -143,7 +153,7 @@ func (r *QuoteRepository) GetQuotes(ctx context.Context, from, to time.Time, lim
 
 	result := query.Find(&entities)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get quotes for token %s: %w", tokenName, result.Error)
+		return nil, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get quotes for token %s: %w", tokenName, result.Error))
 	}
 
 	quotesList := make([]quotes.Quote, len(entities))
@@ -164,14 +174,14 @@ func (r *QuoteRepository) GetQuotes(ctx context.Context, from, to time.Time, lim
 // GetCount returns count of quotes for a specific token
 func (r *QuoteRepository) GetCount(ctx context.Context, tokenName string) (int64, error) {
 	if !quotes.IsTokenSupported(tokenName) {
-		return 0, fmt.Errorf("token '%s' is not supported", tokenName)
+		return 0, quotes.TokenNotFound(tokenName)
 	}
 
 	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
 	var count int64
 	result := r.db.WithContext(ctx).Table(tableName).Count(&count)
 	if result.Error != nil {
-		return 0, fmt.Errorf("failed to get quotes count for token %s: %w", tokenName, result.Error)
+		return 0, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get quotes count for token %s: %w", tokenName, result.Error))
 	}
 
 	return count, nil
@@ -180,7 +190,7 @@ func (r *QuoteRepository) GetCount(ctx context.Context, tokenName string) (int64
 // GetLastTimestamp returns last timestamp for a specific token
 func (r *QuoteRepository) GetLastTimestamp(ctx context.Context, tokenName string) (time.Time, error) {
 	if !quotes.IsTokenSupported(tokenName) {
-		return time.Time{}, fmt.Errorf("token '%s' is not supported", tokenName)
+		return time.Time{}, quotes.TokenNotFound(tokenName)
 	}
 
 	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
@@ -191,15 +201,429 @@ func (r *QuoteRepository) GetLastTimestamp(ctx context.Context, tokenName string
 		Order("timestamp DESC").
 		First(&entity)
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return time.Time{}, fmt.Errorf("no quotes found for token '%s'", tokenName)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return time.Time{}, quotes.NoData(tokenName)
 		}
-		return time.Time{}, fmt.Errorf("failed to get last timestamp for token %s: %w", tokenName, result.Error)
+		return time.Time{}, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get last timestamp for token %s: %w", tokenName, result.Error))
 	}
 
 	return entity.Timestamp, nil
 }
 
+// dailyQuery groups observed (not forward-filled) prices of one currency by calendar day in a
+// time zone. Postgres applies the zone rules, so days across DST changes get their real length.
+// The table and price column are filled in with fmt since they cannot be bound as parameters.
+const dailyQuery = `
+WITH points AS (
+	SELECT timestamp AS ts, %[2]s::double precision AS price
+	FROM %[1]s
+	WHERE deleted_at IS NULL
+		AND timestamp >= ? AND timestamp < ?
+		AND %[2]s > 0
+		AND (filled & ?) = 0
+)
+SELECT
+	(ts AT TIME ZONE ?)::date AS day,
+	(ARRAY_AGG(price ORDER BY ts ASC))[1] AS open,
+	MIN(ts) AS open_at,
+	(ARRAY_AGG(price ORDER BY ts DESC))[1] AS close,
+	MAX(ts) AS close_at,
+	AVG(price) AS average,
+	COUNT(*) AS points
+FROM points
+GROUP BY day
+ORDER BY day`
+
+type dailyRow struct {
+	Day     time.Time
+	Open    float64
+	OpenAt  time.Time
+	Close   float64
+	CloseAt time.Time
+	Average float64
+	Points  int64
+}
+
+// GetDaily returns the observed prices of a token in one currency grouped by calendar day in loc,
+// for the days from the day of from to the day of to. Days without observed prices are left out.
+func (r *QuoteRepository) GetDaily(ctx context.Context, tokenName string, currency quotes.Currency, loc *time.Location, from, to time.Time) ([]quotes.DailyPrice, error) {
+	if !quotes.IsTokenSupported(tokenName) {
+		return nil, quotes.TokenNotFound(tokenName)
+	}
+
+	bit := quotes.FilledBit(currency)
+	if bit == 0 {
+		return nil, fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	if from.After(to) {
+		return nil, quotes.InvalidRange("invalid time range: 'from' must be before 'to'")
+	}
+
+	start := quotes.StartOfDay(from.In(loc))
+	end := quotes.StartOfDay(to.In(loc).AddDate(0, 0, 1))
+
+	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
+	var rows []dailyRow
+	result := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(dailyQuery, tableName, string(currency)), start, end, int16(bit), loc.String()).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get daily prices for token %s: %w", tokenName, result.Error))
+	}
+
+	prices := make([]quotes.DailyPrice, len(rows))
+	for i, row := range rows {
+		year, month, day := row.Day.Date()
+		prices[i] = quotes.DailyPrice{
+			Day:     time.Date(year, month, day, 0, 0, 0, 0, loc),
+			Open:    row.Open,
+			OpenAt:  row.OpenAt,
+			Close:   row.Close,
+			CloseAt: row.CloseAt,
+			Average: row.Average,
+			Points:  row.Points,
+		}
+	}
+
+	return prices, nil
+}
+
+// tickerQuery fetches the latest quote of a token and, for every ticker period, the last quote
+// at or before the latest timestamp minus the period span, in a single round trip.
+// The table name and the periods list are filled in with fmt.
+const tickerQuery = `
+WITH latest AS (
+	SELECT * FROM %[1]s
+	WHERE deleted_at IS NULL
+	ORDER BY timestamp DESC
+	LIMIT 1
+)
+SELECT '' AS period, latest.* FROM latest
+UNION ALL
+SELECT periods.name AS period, previous.*
+FROM latest
+CROSS JOIN (VALUES %[2]s) AS periods(name, seconds)
+CROSS JOIN LATERAL (
+	SELECT * FROM %[1]s
+	WHERE deleted_at IS NULL
+		AND timestamp <= latest.timestamp - periods.seconds * INTERVAL '1 second'
+	ORDER BY timestamp DESC
+	LIMIT 1
+) AS previous`
+
+type tickerRow struct {
+	Period string
+	entities.QuoteEntity
+}
+
+// GetTicker returns the latest quote of a token along with the quotes it is compared to
+// over quotes.TickerPeriods. Returns a NoData error when the token has no quotes.
+func (r *QuoteRepository) GetTicker(ctx context.Context, tokenName string) (quotes.Ticker, error) {
+	if !quotes.IsTokenSupported(tokenName) {
+		return quotes.Ticker{}, quotes.TokenNotFound(tokenName)
+	}
+
+	periods := make([]string, len(quotes.TickerPeriods))
+	for i, period := range quotes.TickerPeriods {
+		periods[i] = fmt.Sprintf("('%s', %d)", period.Name, int64(period.Span/time.Second))
+	}
+
+	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
+	var rows []tickerRow
+	result := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(tickerQuery, tableName, strings.Join(periods, ", "))).
+		Scan(&rows)
+	if result.Error != nil {
+		return quotes.Ticker{}, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get ticker for token %s: %w", tokenName, result.Error))
+	}
+
+	ticker := quotes.Ticker{Token: strings.ToLower(tokenName), Previous: make(map[string]quotes.Quote)}
+	found := false
+	for _, row := range rows {
+		if row.Period == "" {
+			ticker.Latest = r.entityToDomain(row.QuoteEntity)
+			found = true
+			continue
+		}
+		ticker.Previous[row.Period] = r.entityToDomain(row.QuoteEntity)
+	}
+	if !found {
+		return quotes.Ticker{}, quotes.NoData(tokenName)
+	}
+
+	return ticker, nil
+}
+
+// quotesAtQuery looks up, for every timestamp of an array, the last quote at or before it.
+// Rows keep the order of the array; the quote columns are NULL when there is no such quote.
+const quotesAtQuery = `
+SELECT points.idx, previous.*
+FROM unnest(?::timestamptz[]) WITH ORDINALITY AS points(at, idx)
+LEFT JOIN LATERAL (
+	SELECT * FROM %s
+	WHERE deleted_at IS NULL AND timestamp <= points.at
+	ORDER BY timestamp DESC
+	LIMIT 1
+) AS previous ON TRUE
+ORDER BY points.idx`
+
+type quoteAtRow struct {
+	Idx          int64
+	ID           sql.NullInt64
+	Timestamp    sql.NullTime
+	BTC          sql.NullFloat64
+	USD          sql.NullFloat64
+	EUR          sql.NullFloat64
+	CNY          sql.NullFloat64
+	JPY          sql.NullFloat64
+	KRW          sql.NullFloat64
+	ETH          sql.NullFloat64
+	GBP          sql.NullFloat64
+	Volume24hUSD sql.NullFloat64 `gorm:"column:volume_24h_usd"`
+	Filled       sql.NullInt16
+}
+
+// GetQuotesAt returns, for every timestamp, the last quote of a token at or before it, in a
+// single query. The quote is zero (zero Timestamp) where the token had no quote yet.
+func (r *QuoteRepository) GetQuotesAt(ctx context.Context, tokenName string, timestamps []time.Time) ([]quotes.Quote, error) {
+	if !quotes.IsTokenSupported(tokenName) {
+		return nil, quotes.TokenNotFound(tokenName)
+	}
+	if len(timestamps) == 0 {
+		return nil, nil
+	}
+
+	// Passed as one array literal: gorm would expand a slice into a parameter per element
+	literals := make([]string, len(timestamps))
+	for i, timestamp := range timestamps {
+		literals[i] = timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	array := "{" + strings.Join(literals, ",") + "}"
+
+	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
+	var rows []quoteAtRow
+	result := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(quotesAtQuery, tableName), array).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get quotes at timestamps for token %s: %w", tokenName, result.Error))
+	}
+
+	quotesList := make([]quotes.Quote, len(timestamps))
+	for _, row := range rows {
+		i := int(row.Idx) - 1
+		if i < 0 || i >= len(quotesList) || !row.Timestamp.Valid {
+			continue
+		}
+		quotesList[i] = quotes.Quote{
+			Timestamp: row.Timestamp.Time,
+			BTC:       row.BTC.Float64,
+			USD:       row.USD.Float64,
+			EUR:       row.EUR.Float64,
+			CNY:       row.CNY.Float64,
+			JPY:       row.JPY.Float64,
+			KRW:       row.KRW.Float64,
+			ETH:       row.ETH.Float64,
+			GBP:       row.GBP.Float64,
+			Volume24h: row.Volume24hUSD.Float64,
+			Filled:    uint16(row.Filled.Int16),
+		}
+	}
+
+	return quotesList, nil
+}
+
+// pairQuery aligns every base token quote with the nearest quote token quote within the
+// tolerance, both priced in the pivot currency. Table and column names are filled in with fmt.
+const pairQuery = `
+SELECT base.timestamp AS timestamp, base.%[3]s::double precision AS base_price,
+	nearest.timestamp AS quote_timestamp, nearest.price AS quote_price
+FROM %[1]s AS base
+CROSS JOIN LATERAL (
+	SELECT other.timestamp, other.%[3]s::double precision AS price
+	FROM %[2]s AS other
+	WHERE other.deleted_at IS NULL
+		AND other.%[3]s > 0
+		AND other.timestamp BETWEEN base.timestamp - ? * INTERVAL '1 second' AND base.timestamp + ? * INTERVAL '1 second'
+	ORDER BY ABS(EXTRACT(EPOCH FROM other.timestamp - base.timestamp)), other.timestamp DESC
+	LIMIT 1
+) AS nearest
+WHERE base.deleted_at IS NULL
+	AND base.%[3]s > 0
+	%[4]s
+ORDER BY base.timestamp %[5]s
+%[6]s`
+
+type pairRow struct {
+	Timestamp      time.Time
+	BasePrice      float64
+	QuoteTimestamp time.Time
+	QuotePrice     float64
+}
+
+// GetPairQuotes derives the series of a synthetic pair between two tokens through a pivot
+// currency. Base quotes without a quote token quote within tolerance are skipped.
+// Like GetQuotes, zero from and to return the latest quotes up to limit.
+func (r *QuoteRepository) GetPairQuotes(ctx context.Context, baseToken, quoteToken string, pivot quotes.Currency, tolerance time.Duration, from, to time.Time, limit int) ([]quotes.PairQuote, error) {
+	for _, tokenName := range []string{baseToken, quoteToken} {
+		if !quotes.IsTokenSupported(tokenName) {
+			return nil, quotes.TokenNotFound(tokenName)
+		}
+	}
+	if quotes.FilledBit(pivot) == 0 {
+		return nil, fmt.Errorf("unsupported currency %q", pivot)
+	}
+	if from.After(to) {
+		return nil, quotes.InvalidRange("invalid time range: 'from' must be before 'to'")
+	}
+
+	seconds := tolerance.Seconds()
+	args := []interface{}{seconds, seconds}
+
+	latest := from.IsZero() && to.IsZero()
+	rangeFilter, order, limitClause := "", "ASC", ""
+	if latest {
+		order = "DESC"
+	} else {
+		rangeFilter = "AND base.timestamp >= ? AND base.timestamp <= ?"
+		args = append(args, from, to)
+	}
+	if limit > 0 {
+		limitClause = "LIMIT ?"
+		args = append(args, limit)
+	}
+
+	query := fmt.Sprintf(pairQuery,
+		fmt.Sprintf("mev.%s", tokenNameToTableName(baseToken)),
+		fmt.Sprintf("mev.%s", tokenNameToTableName(quoteToken)),
+		string(pivot), rangeFilter, order, limitClause)
+
+	var rows []pairRow
+	result := r.db.WithContext(ctx).Raw(query, args...).Scan(&rows)
+	if result.Error != nil {
+		return nil, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get %s/%s pair quotes: %w", baseToken, quoteToken, result.Error))
+	}
+
+	pairQuotes := make([]quotes.PairQuote, len(rows))
+	for i, row := range rows {
+		pairQuotes[i] = quotes.NewPairQuote(row.Timestamp, row.BasePrice, row.QuoteTimestamp, row.QuotePrice)
+	}
+
+	// Reverse order if we got latest quotes (DESC -> ASC for response)
+	if latest {
+		for i, j := 0, len(pairQuotes)-1; i < j; i, j = i+1, j-1 {
+			pairQuotes[i], pairQuotes[j] = pairQuotes[j], pairQuotes[i]
+		}
+	}
+
+	return pairQuotes, nil
+}
+
+// statsQuery computes range statistics over observed (not forward-filled) prices of one currency.
+// The table and price column are filled in with fmt since they cannot be bound as parameters.
+const statsQuery = `
+WITH points AS (
+	SELECT timestamp AS ts, %[2]s::double precision AS price
+	FROM %[1]s
+	WHERE deleted_at IS NULL
+		AND timestamp >= ? AND timestamp <= ?
+		AND %[2]s > 0
+		AND (filled & ?) = 0
+), series AS (
+	SELECT ts, price,
+		LN(price / LAG(price) OVER (ORDER BY ts)) AS log_return,
+		MAX(price) OVER (ORDER BY ts ROWS UNBOUNDED PRECEDING) AS peak
+	FROM points
+)
+SELECT
+	COUNT(*) AS count,
+	MIN(price) AS min_price,
+	(ARRAY_AGG(ts ORDER BY price ASC, ts ASC))[1] AS min_at,
+	MAX(price) AS max_price,
+	(ARRAY_AGG(ts ORDER BY price DESC, ts ASC))[1] AS max_at,
+	AVG(price) AS mean,
+	COALESCE(STDDEV_SAMP(price), 0) AS std_dev,
+	(ARRAY_AGG(price ORDER BY ts ASC))[1] AS first_price,
+	MIN(ts) AS first_at,
+	(ARRAY_AGG(price ORDER BY ts DESC))[1] AS last_price,
+	MAX(ts) AS last_at,
+	COALESCE(SQRT(SUM(log_return * log_return)), 0) AS volatility,
+	COALESCE(MAX((peak - price) / peak), 0) AS max_drawdown,
+	(ARRAY_AGG(ts ORDER BY (peak - price) / peak DESC, ts ASC))[1] AS max_drawdown_at
+FROM series`
+
+type statsRow struct {
+	Count         int64
+	MinPrice      sql.NullFloat64
+	MinAt         sql.NullTime
+	MaxPrice      sql.NullFloat64
+	MaxAt         sql.NullTime
+	Mean          sql.NullFloat64
+	StdDev        float64
+	FirstPrice    sql.NullFloat64
+	FirstAt       sql.NullTime
+	LastPrice     sql.NullFloat64
+	LastAt        sql.NullTime
+	Volatility    float64
+	MaxDrawdown   float64
+	MaxDrawdownAt sql.NullTime
+}
+
+// GetStats computes price statistics of a token in one currency within a time range.
+// Returns a NoData error when no price was observed in the range.
+func (r *QuoteRepository) GetStats(ctx context.Context, tokenName string, currency quotes.Currency, from, to time.Time) (quotes.Stats, error) {
+	if !quotes.IsTokenSupported(tokenName) {
+		return quotes.Stats{}, quotes.TokenNotFound(tokenName)
+	}
+
+	bit := quotes.FilledBit(currency)
+	if bit == 0 {
+		return quotes.Stats{}, fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	if from.After(to) {
+		return quotes.Stats{}, quotes.InvalidRange("invalid time range: 'from' must be before 'to'")
+	}
+
+	tableName := fmt.Sprintf("mev.%s", tokenNameToTableName(tokenName))
+	var row statsRow
+	result := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(statsQuery, tableName, string(currency)), from, to, int16(bit)).
+		Scan(&row)
+	if result.Error != nil {
+		return quotes.Stats{}, quotes.UpstreamUnavailable("database", fmt.Errorf("failed to get stats for token %s: %w", tokenName, result.Error))
+	}
+
+	if row.Count == 0 {
+		return quotes.Stats{}, quotes.NoData(tokenName)
+	}
+
+	stats := quotes.Stats{
+		Currency:    currency,
+		From:        from,
+		To:          to,
+		Count:       row.Count,
+		Min:         row.MinPrice.Float64,
+		MinAt:       row.MinAt.Time,
+		Max:         row.MaxPrice.Float64,
+		MaxAt:       row.MaxAt.Time,
+		Mean:        row.Mean.Float64,
+		StdDev:      row.StdDev,
+		First:       row.FirstPrice.Float64,
+		FirstAt:     row.FirstAt.Time,
+		Last:        row.LastPrice.Float64,
+		LastAt:      row.LastAt.Time,
+		Volatility:  row.Volatility,
+		MaxDrawdown: row.MaxDrawdown,
+	}
+	if stats.MaxDrawdown > 0 {
+		stats.MaxDrawdownAt = row.MaxDrawdownAt.Time
+	}
+
+	return stats, nil
+}
+
 func (r *QuoteRepository) entityToDomain(entity entities.QuoteEntity) quotes.Quote {
 	return quotes.Quote{
 		Timestamp: entity.Timestamp,
@@ -211,5 +635,7 @@ func (r *QuoteRepository) entityToDomain(entity entities.QuoteEntity) quotes.Quo
 		KRW:       entity.KRW,
 		ETH:       entity.ETH,
 		GBP:       entity.GBP,
+		Volume24h: entity.Volume24hUSD,
+		Filled:    uint16(entity.Filled),
 	}
 }
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Component is a long-running part of the service (servers, collectors, workers)
+type Component interface {
+	// Run starts the component and blocks until it stops. It returns nil when stopped through
+	// Stop or ctx, or when it has simply finished its work; an error makes the manager shut down.
+	Run(ctx context.Context) error
+	// Stop asks the component to stop and waits until it has, giving up when ctx expires
+	Stop(ctx context.Context) error
+}
+
+type namedComponent struct {
+	name      string
+	component Component
+	done      chan struct{}
+}
+
+// Manager runs the components of the service and shuts them down together
+type Manager struct {
+	timeout    time.Duration
+	components []*namedComponent
+}
+
+func NewManager(timeout time.Duration) *Manager {
+	return &Manager{timeout: timeout}
+}
+
+// Add registers a component. Components are stopped in the reverse order they were added,
+// so servers should be added after the workers they depend on.
+func (m *Manager) Add(name string, component Component) {
+	m.components = append(m.components, &namedComponent{
+		name:      name,
+		component: component,
+		done:      make(chan struct{}),
+	})
+}
+
+// Run starts every component and blocks until ctx is cancelled or a component fails, then
+// stops all of them within the shutdown timeout. The returned error names every component
+// that failed to run or to stop in time.
+func (m *Manager) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	failures := make(chan error, len(m.components))
+	for _, c := range m.components {
+		go func(c *namedComponent) {
+			defer close(c.done)
+			if err := c.component.Run(runCtx); err != nil {
+				failures <- fmt.Errorf("%s: %w", c.name, err)
+			}
+		}(c)
+	}
+
+	var errs []error
+	select {
+	case <-ctx.Done():
+		log.Println("Shutting down...")
+	case err := <-failures:
+		log.Printf("Component failed, shutting down: %v", err)
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, m.shutdown(cancel)...)
+
+	// Components that failed while others were stopping
+	for len(failures) > 0 {
+		errs = append(errs, <-failures)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	log.Println("Shutdown complete")
+	return nil
+}
+
+func (m *Manager) shutdown(cancel context.CancelFunc) []error {
+	ctx, shutdownCancel := context.WithTimeout(context.Background(), m.timeout)
+	defer shutdownCancel()
+
+	var errs []error
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		if err := c.component.Stop(ctx); err != nil {
+			log.Printf("Failed to stop %s: %v", c.name, err)
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", c.name, err))
+		} else {
+			log.Printf("Stopped %s", c.name)
+		}
+	}
+
+	// Run returns shortly after a successful Stop; components that failed to stop are abandoned
+	cancel()
+	for _, c := range m.components {
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return errs
+		}
+	}
+	return errs
+}
@@ -39,14 +39,14 @@ execute_migration() {
 }
 
 if [ "$COMMAND" = "up" ]; then
-    for file in $(find "${MIGRATIONS_DIR}" -type f \( -name "*_up.sql" -o -name "*.sql" \) ! -name "*_down.sql" | sort -V); do
+    for file in $(find "${MIGRATIONS_DIR}" -type f \( -name "*_up.sql" -o -name "*.sql" \) ! -name "*_down.sql" ! -name "*.down.sql" | sort -V); do
         case "$file" in
-            *_down.sql) continue ;;
+            *_down.sql|*.down.sql) continue ;;
         esac
         execute_migration "$file"
     done
 elif [ "$COMMAND" = "down" ]; then
-    for file in $(find "${MIGRATIONS_DIR}" -type f -name "*_down.sql" | sort -Vr); do
+    for file in $(find "${MIGRATIONS_DIR}" -type f \( -name "*_down.sql" -o -name "*.down.sql" \) | sort -Vr); do
         execute_migration "$file"
     done
 else